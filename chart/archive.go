@@ -0,0 +1,132 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chart
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// maxArchiveSize limits the decompressed size of a chart archive, the same
+// default limit as Helm.
+const maxArchiveSize = 100 << 20
+
+// errStopWalk stops walkArchive without error.
+var errStopWalk = errors.New("stop walk")
+
+// walkArchive calls fn for every regular file of a chart archive, with its
+// name relative to the chart directory at the top of the archive.
+func walkArchive(archive string, fn func(name string, contents io.Reader) error) error {
+	file, err := os.Open(archive)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		return err
+	}
+	defer gzipReader.Close()
+
+	reader := tar.NewReader(io.LimitReader(gzipReader, maxArchiveSize))
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		// Archive names always use forward slashes, the first component is
+		// the directory of the chart.
+		name := path.Clean(strings.TrimPrefix(header.Name, "/"))
+		_, name, found := strings.Cut(name, "/")
+		if !found || name == ".." || strings.HasPrefix(name, "../") {
+			continue
+		}
+
+		if err := fn(name, reader); err != nil {
+			if errors.Is(err, errStopWalk) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// loadArchive reads the Chart.yaml file of a chart archive.
+func loadArchive(archive string) (*Metadata, error) {
+	var metadata *Metadata
+	err := walkArchive(archive, func(name string, contents io.Reader) error {
+		if name != MetadataFile {
+			return nil
+		}
+
+		metadata = &Metadata{}
+		if err := yaml.NewDecoder(contents).Decode(metadata); err != nil {
+			return fmt.Errorf("could not parse %s: %w", MetadataFile, err)
+		}
+
+		return errStopWalk
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata == nil {
+		return nil, fmt.Errorf("%s not found in %q", MetadataFile, archive)
+	}
+
+	return metadata, nil
+}
+
+// extractArchive writes the files of a chart archive to the directory.
+func extractArchive(archive string, dir string) error {
+	return walkArchive(archive, func(name string, contents io.Reader) error {
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+
+		file, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+		if err != nil {
+			return err
+		}
+
+		if _, err := io.Copy(file, contents); err != nil {
+			file.Close()
+			return err
+		}
+
+		return file.Close()
+	})
+}
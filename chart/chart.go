@@ -0,0 +1,188 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chart
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"go.yaml.in/yaml/v3"
+)
+
+const (
+	MetadataFile = "Chart.yaml"
+	ValuesFile   = "values.yaml"
+	SchemaFile   = "values.schema.json"
+	ChartsDir    = "charts"
+)
+
+// Metadata contains the fields of a Chart.yaml file that are relevant for
+// generating documentation and schemas.
+type Metadata struct {
+	APIVersion   string       `yaml:"apiVersion"`
+	Name         string       `yaml:"name"`
+	Version      string       `yaml:"version"`
+	Dependencies []Dependency `yaml:"dependencies"`
+
+	Description string       `yaml:"description"`
+	Type        string       `yaml:"type"`
+	AppVersion  string       `yaml:"appVersion"`
+	KubeVersion string       `yaml:"kubeVersion"`
+	Home        string       `yaml:"home"`
+	Icon        string       `yaml:"icon"`
+	Sources     []string     `yaml:"sources"`
+	Keywords    []string     `yaml:"keywords"`
+	Maintainers []Maintainer `yaml:"maintainers"`
+	Deprecated  bool         `yaml:"deprecated"`
+}
+
+// Maintainer is a single entry of the maintainers list in Chart.yaml.
+type Maintainer struct {
+	Name  string `yaml:"name"`
+	Email string `yaml:"email"`
+	URL   string `yaml:"url"`
+}
+
+// Dependency is a single entry of the dependencies list in Chart.yaml.
+type Dependency struct {
+	Name       string `yaml:"name"`
+	Version    string `yaml:"version"`
+	Repository string `yaml:"repository"`
+	Condition  string `yaml:"condition"`
+	Alias      string `yaml:"alias"`
+
+	ImportValues []ImportValue `yaml:"import-values"`
+}
+
+// ImportValue is a single entry of the import-values list of a dependency,
+// copying the values at the Child path of the subchart to the Parent path of
+// the parent chart. The short form "data" imports the "exports.data" values of
+// the subchart into the root of the parent values.
+type ImportValue struct {
+	Child  string `yaml:"child"`
+	Parent string `yaml:"parent"`
+}
+
+func (i *ImportValue) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*i = ImportValue{Child: "exports." + node.Value}
+		return nil
+	}
+
+	type plain ImportValue
+	return node.Decode((*plain)(i))
+}
+
+// Key returns the key under which the values of the dependency are nested in
+// the values of the parent chart.
+func (d Dependency) Key() string {
+	if d.Alias != "" {
+		return d.Alias
+	}
+
+	return d.Name
+}
+
+// Load reads the Chart.yaml file in the chart directory.
+func Load(chartDir string) (*Metadata, error) {
+	contents, err := os.ReadFile(filepath.Join(chartDir, MetadataFile))
+	if err != nil {
+		return nil, err
+	}
+
+	var metadata Metadata
+	if err := yaml.Unmarshal(contents, &metadata); err != nil {
+		return nil, fmt.Errorf("could not parse %s: %w", MetadataFile, err)
+	}
+
+	return &metadata, nil
+}
+
+// Subchart is a dependency found in the charts/ directory of its parent.
+type Subchart struct {
+	Dependency Dependency
+	// Dir is the directory of the subchart. Subcharts packaged as .tgz
+	// archives are extracted to a temporary directory, removed by Close.
+	Dir string
+
+	temporary bool
+}
+
+// Close removes the directory the subchart was extracted to, if any.
+func (s *Subchart) Close() error {
+	if !s.temporary {
+		return nil
+	}
+
+	return os.RemoveAll(s.Dir)
+}
+
+// OpenSubchart finds the dependency in the charts/ directory of the parent
+// chart. Helm stores dependencies either as a directory, under their own name
+// or under any name as long as its Chart.yaml has the dependency's name, or as
+// a .tgz archive.
+func OpenSubchart(chartDir string, dependency Dependency) (*Subchart, error) {
+	chartsDir := filepath.Join(chartDir, ChartsDir)
+
+	candidate := filepath.Join(chartsDir, dependency.Name)
+	if metadata, err := Load(candidate); err == nil && metadata.Name == dependency.Name {
+		return &Subchart{Dependency: dependency, Dir: candidate}, nil
+	}
+
+	entries, err := os.ReadDir(chartsDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		candidate := filepath.Join(chartsDir, entry.Name())
+		if metadata, err := Load(candidate); err == nil && metadata.Name == dependency.Name {
+			return &Subchart{Dependency: dependency, Dir: candidate}, nil
+		}
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".tgz" {
+			continue
+		}
+
+		archive := filepath.Join(chartsDir, entry.Name())
+		metadata, err := loadArchive(archive)
+		if err != nil || metadata.Name != dependency.Name {
+			continue
+		}
+
+		dir, err := os.MkdirTemp("", "helm-tool-"+dependency.Name+"-")
+		if err != nil {
+			return nil, err
+		}
+
+		if err := extractArchive(archive, dir); err != nil {
+			os.RemoveAll(dir)
+			return nil, fmt.Errorf("could not extract %q: %w", archive, err)
+		}
+
+		return &Subchart{Dependency: dependency, Dir: dir, temporary: true}, nil
+	}
+
+	return nil, fmt.Errorf("dependency %q not found in %q, run \"helm dependency build\" first", dependency.Name, chartsDir)
+}
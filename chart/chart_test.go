@@ -0,0 +1,109 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chart
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeArchive(t *testing.T, path string, files map[string]string) {
+	t.Helper()
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	gzipWriter := gzip.NewWriter(file)
+	writer := tar.NewWriter(gzipWriter)
+	for name, content := range files {
+		require.NoError(t, writer.WriteHeader(&tar.Header{Name: name, Mode: 0600, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := writer.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+	require.NoError(t, gzipWriter.Close())
+}
+
+func TestLoad_ImportValues(t *testing.T) {
+	chartDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(chartDir, MetadataFile), []byte(`
+name: umbrella
+dependencies:
+- name: lib
+  import-values:
+  - data
+  - child: default.settings
+    parent: settings
+`), 0600))
+
+	metadata, err := Load(chartDir)
+	require.NoError(t, err)
+	assert.Equal(t, []ImportValue{
+		{Child: "exports.data"},
+		{Child: "default.settings", Parent: "settings"},
+	}, metadata.Dependencies[0].ImportValues)
+}
+
+func TestOpenSubchart_Archive(t *testing.T) {
+	chartDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(chartDir, ChartsDir), 0755))
+	writeArchive(t, filepath.Join(chartDir, ChartsDir, "other-1.0.0.tgz"), map[string]string{
+		"other/Chart.yaml": "name: other\n",
+	})
+	writeArchive(t, filepath.Join(chartDir, ChartsDir, "lib-1.0.0.tgz"), map[string]string{
+		"lib/Chart.yaml":         "name: lib\n",
+		"lib/values.yaml":        "name: lib\n",
+		"lib/../../escaped.yaml": "escaped: true\n",
+		"lib/templates/_lib.tpl": "",
+	})
+
+	subchart, err := OpenSubchart(chartDir, Dependency{Name: "lib"})
+	require.NoError(t, err)
+
+	values, err := os.ReadFile(filepath.Join(subchart.Dir, ValuesFile))
+	require.NoError(t, err)
+	assert.Equal(t, "name: lib\n", string(values))
+	assert.FileExists(t, filepath.Join(subchart.Dir, "templates", "_lib.tpl"))
+	assert.NoFileExists(t, filepath.Join(filepath.Dir(subchart.Dir), "escaped.yaml"))
+
+	require.NoError(t, subchart.Close())
+	assert.NoDirExists(t, subchart.Dir)
+
+	_, err = OpenSubchart(chartDir, Dependency{Name: "missing"})
+	require.ErrorContains(t, err, "helm dependency build")
+}
+
+func TestOpenSubchart_Directory(t *testing.T) {
+	chartDir := t.TempDir()
+	subchartDir := filepath.Join(chartDir, ChartsDir, "renamed")
+	require.NoError(t, os.MkdirAll(subchartDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(subchartDir, MetadataFile), []byte("name: lib\n"), 0600))
+
+	subchart, err := OpenSubchart(chartDir, Dependency{Name: "lib"})
+	require.NoError(t, err)
+	assert.Equal(t, subchartDir, subchart.Dir)
+
+	// Directories found in charts/ are not removed
+	require.NoError(t, subchart.Close())
+	assert.DirExists(t, subchartDir)
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package dump serializes the parsed document model for other tools. The
+// format is versioned: fields may be added within a version, but fields are
+// only removed or changed in a new version.
+package dump
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/cert-manager/helm-tool/heuristics"
+	"github.com/cert-manager/helm-tool/parser"
+	"github.com/cert-manager/helm-tool/paths"
+)
+
+// Version is the version of the format.
+const Version = "helm-tool.cert-manager.io/v1"
+
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+// Document is the documentation of a values file.
+type Document struct {
+	// Version is the version of the format, see Version.
+	Version  string    `json:"version" yaml:"version"`
+	Sections []Section `json:"sections" yaml:"sections"`
+}
+
+// Section is a group of properties, started by a +docs:section tag. The
+// properties before the first tag are in a section without name.
+type Section struct {
+	Name        string     `json:"name" yaml:"name"`
+	Description []Segment  `json:"description" yaml:"description"`
+	Properties  []Property `json:"properties" yaml:"properties"`
+}
+
+// Property is a documented value.
+type Property struct {
+	// Path is the path of the value, eg. image.tag, podLabels["a.b"] or
+	// extraArgs[0].
+	Path string `json:"path" yaml:"path"`
+	// PathComponents is the path as a list of property names (strings) and
+	// array indices (numbers).
+	PathComponents []any `json:"pathComponents" yaml:"pathComponents"`
+	// Type is one of string, number, bool, timestamp, array, object or
+	// unknown, or the value of a +docs:type tag.
+	Type string `json:"type" yaml:"type"`
+	// Format is the format of string values, eg. duration or url, if known.
+	Format string `json:"format,omitempty" yaml:"format,omitempty"`
+	// Default is the default value, parsed as YAML. It is missing for
+	// properties without a default and for null defaults, DefaultYAML
+	// tells them apart.
+	Default any `json:"default,omitempty" yaml:"default,omitempty"`
+	// DefaultYAML is the default value as written in the values file.
+	DefaultYAML string    `json:"defaultYaml,omitempty" yaml:"defaultYaml,omitempty"`
+	Description []Segment `json:"description" yaml:"description"`
+	// Tags are the +docs tags of the property, keyed by their name without
+	// the leading '+' (eg. "docs:type"), with all values in order.
+	Tags map[string][]string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	// RequiredWhen and ExclusiveWith are the paths of the related
+	// properties, see +docs:requiredWhen and +docs:exclusiveWith.
+	RequiredWhen  []string `json:"requiredWhen,omitempty" yaml:"requiredWhen,omitempty"`
+	ExclusiveWith []string `json:"exclusiveWith,omitempty" yaml:"exclusiveWith,omitempty"`
+	// See are the paths of the related properties, see +docs:see.
+	See []string `json:"see,omitempty" yaml:"see,omitempty"`
+	// Ref is the shared definition of the property, see +docs:ref.
+	Ref string `json:"ref,omitempty" yaml:"ref,omitempty"`
+	// Deprecation is the deprecation message, for deprecated properties.
+	Deprecation string `json:"deprecation,omitempty" yaml:"deprecation,omitempty"`
+}
+
+// Segment is a part of a comment.
+type Segment struct {
+	// Type is text, yaml (an example) or tag.
+	Type heuristics.ContentType `json:"type" yaml:"type"`
+	// Text is the content of the segment, for yaml segments the common
+	// indentation is removed.
+	Text string `json:"text" yaml:"text"`
+}
+
+// New converts the parsed document.
+func New(document *parser.Document) *Document {
+	result := &Document{
+		Version:  Version,
+		Sections: []Section{},
+	}
+
+	for _, section := range document.Sections {
+		converted := Section{
+			Name:        section.Name,
+			Description: segments(section.Description),
+			Properties:  []Property{},
+		}
+
+		for _, property := range section.Properties {
+			converted.Properties = append(converted.Properties, newProperty(property))
+		}
+
+		result.Sections = append(result.Sections, converted)
+	}
+
+	return result
+}
+
+func newProperty(property parser.Property) Property {
+	result := Property{
+		Path:           property.Path.String(),
+		PathComponents: pathComponents(property.Path),
+		Type:           property.Type.String(),
+		Format:         string(property.Format),
+		DefaultYAML:    property.Default,
+		Description:    segments(property.Description),
+		RequiredWhen:   pathStrings(property.RequiredWhen),
+		ExclusiveWith:  pathStrings(property.ExclusiveWith),
+		See:            pathStrings(property.See),
+	}
+
+	if len(property.Description.Tags) > 0 {
+		result.Tags = map[string][]string(property.Description.Tags)
+	}
+
+	// Defaults that are not valid YAML are kept as a string
+	if property.Default != "" {
+		if err := yaml.Unmarshal([]byte(property.Default), &result.Default); err != nil {
+			result.Default = property.Default
+		}
+	}
+
+	if property.Ref != nil {
+		result.Ref = property.Ref.File + "#" + property.Ref.Pointer
+	}
+
+	if property.IsDeprecated() {
+		result.Deprecation = property.DeprecationMessage()
+	}
+
+	return result
+}
+
+func segments(comment parser.Comment) []Segment {
+	result := []Segment{}
+	for _, segment := range comment.Segments {
+		result = append(result, Segment{
+			Type: segment.Type,
+			Text: segment.String(),
+		})
+	}
+
+	return result
+}
+
+func pathComponents(path paths.Path) []any {
+	result := []any{}
+	for _, component := range path {
+		if idx, ok := paths.ArrayIndex(component); ok {
+			result = append(result, idx)
+		} else {
+			name, _ := paths.PropertyName(component)
+			result = append(result, name)
+		}
+	}
+
+	return result
+}
+
+func pathStrings(list []paths.Path) []string {
+	var result []string
+	for _, path := range list {
+		result = append(result, path.String())
+	}
+
+	return result
+}
+
+// Render serializes the document in the format.
+func Render(document *parser.Document, format Format) (string, error) {
+	dumped := New(document)
+
+	switch format {
+	case FormatJSON:
+		data, err := json.MarshalIndent(dumped, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+
+	case FormatYAML:
+		var sb strings.Builder
+		encoder := yaml.NewEncoder(&sb)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(dumped); err != nil {
+			return "", err
+		}
+		if err := encoder.Close(); err != nil {
+			return "", err
+		}
+		return sb.String(), nil
+
+	default:
+		return "", fmt.Errorf("unknown format %q, expected %q or %q", format, FormatJSON, FormatYAML)
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dump
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/cert-manager/helm-tool/parser"
+)
+
+const values = `
+# +docs:section=Images
+
+# The image to use.
+# +docs:deprecated=Use image.repository instead.
+image: example.com/app
+
+podLabels:
+  # A label with dots.
+  app.kubernetes.io/name: app
+
+extraArgs:
+  - --v=2
+`
+
+func loadDocument(t *testing.T) *parser.Document {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "values.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(values), 0600))
+
+	document, err := parser.Load(path, false)
+	require.NoError(t, err)
+	return document
+}
+
+func TestRender(t *testing.T) {
+	document := loadDocument(t)
+
+	for _, format := range []Format{FormatJSON, FormatYAML} {
+		t.Run(string(format), func(t *testing.T) {
+			rendered, err := Render(document, format)
+			require.NoError(t, err)
+
+			var result map[string]any
+			if format == FormatJSON {
+				require.NoError(t, json.Unmarshal([]byte(rendered), &result))
+			} else {
+				require.NoError(t, yaml.Unmarshal([]byte(rendered), &result))
+			}
+			assert.Equal(t, Version, result["version"])
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	dumped := New(loadDocument(t))
+
+	require.Len(t, dumped.Sections, 2)
+	section := dumped.Sections[1]
+	assert.Equal(t, "Images", section.Name)
+	require.Len(t, section.Properties, 3)
+
+	image := section.Properties[0]
+	assert.Equal(t, "image", image.Path)
+	assert.Equal(t, "string", image.Type)
+	assert.Equal(t, "image", image.Format)
+	assert.Equal(t, "example.com/app", image.Default)
+	assert.Equal(t, "Use image.repository instead.", image.Deprecation)
+	assert.Equal(t, []string{"Use image.repository instead."}, image.Tags["docs:deprecated"])
+	assert.Contains(t, image.Description, Segment{Type: "text", Text: "The image to use."})
+
+	label := section.Properties[1]
+	assert.Equal(t, `podLabels["app.kubernetes.io/name"]`, label.Path)
+	assert.Equal(t, []any{"podLabels", "app.kubernetes.io/name"}, label.PathComponents)
+
+	arg := section.Properties[2]
+	assert.Equal(t, []any{"extraArgs", 0}, arg.PathComponents)
+
+	_, err := Render(&parser.Document{}, "xml")
+	assert.ErrorContains(t, err, `unknown format "xml"`)
+}
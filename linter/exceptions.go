@@ -0,0 +1,225 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package linter
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/cert-manager/helm-tool/paths"
+)
+
+// Exception suppresses the findings of a rule whose path matches a glob (see
+// paths.CompileGlob). It is written as "<rule>: <glob>" in the exceptions
+// file, eg. "unused: extraObjects[*].**".
+type Exception struct {
+	Rule string
+	Glob string
+	// Line is the line of the exception in the exceptions file.
+	Line int
+
+	exp *regexp.Regexp
+}
+
+func (e Exception) String() string {
+	return e.Rule + ": " + e.Glob
+}
+
+// Matches returns true if the exception suppresses the finding.
+func (e Exception) Matches(finding Finding) bool {
+	return e.Rule == finding.Rule && e.exp.MatchString(finding.Path)
+}
+
+// legacyExceptions maps the messages listed by exceptions files of previous
+// versions, which matched the exact message of a finding, to their rule.
+var legacyExceptions = []struct {
+	prefix string
+	rule   string
+}{
+	{"value missing from values.yaml: ", RuleUndefined},
+	{"value missing from templates: ", RuleUnused},
+	{"see target missing from values.yaml: ", RuleSeeTarget},
+}
+
+// LoadExceptions reads an exceptions file.
+func LoadExceptions(exceptionsPath string) ([]Exception, error) {
+	contents, err := os.ReadFile(exceptionsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	exceptions, err := ParseExceptions(string(contents))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", exceptionsPath, err)
+	}
+
+	return exceptions, nil
+}
+
+// ParseExceptions parses the exceptions of an exceptions file, one per line.
+// Empty lines and lines starting with # are ignored.
+func ParseExceptions(contents string) ([]Exception, error) {
+	var exceptions []Exception
+	for i, line := range strings.Split(contents, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		exception, err := parseException(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+
+		exception.Line = i + 1
+		exceptions = append(exceptions, exception)
+	}
+
+	return exceptions, nil
+}
+
+func parseException(line string) (Exception, error) {
+	var exception Exception
+	for _, legacy := range legacyExceptions {
+		if path, ok := strings.CutPrefix(line, legacy.prefix); ok {
+			path, _, _ = strings.Cut(path, " (referenced by ")
+			exception.Rule, exception.Glob = legacy.rule, path
+			break
+		}
+	}
+
+	if exception.Rule == "" {
+		rule, glob, found := strings.Cut(line, ":")
+		if !found {
+			return Exception{}, fmt.Errorf("invalid exception %q, expected \"<rule>: <path glob>\"", line)
+		}
+		exception.Rule, exception.Glob = strings.TrimSpace(rule), strings.TrimSpace(glob)
+	}
+
+	if exception.Rule == RuleStaleException || !slices.ContainsFunc(rules, func(r rule) bool { return r.id == exception.Rule }) {
+		return Exception{}, fmt.Errorf("unknown rule %q, expected %q, %q or %q", exception.Rule, RuleUndefined, RuleUnused, RuleSeeTarget)
+	}
+
+	exp, err := paths.CompileGlob(exception.Glob)
+	if err != nil {
+		return Exception{}, err
+	}
+	exception.exp = exp
+
+	return exception, nil
+}
+
+// applyExceptions removes the findings suppressed by an exception, and adds
+// a stale-exception finding for every exception that suppresses nothing.
+func applyExceptions(findings []Finding, exceptions []Exception, exceptionsPath string) []Finding {
+	used := make([]bool, len(exceptions))
+	findings = slices.DeleteFunc(findings, func(finding Finding) bool {
+		suppressed := false
+		for i, exception := range exceptions {
+			if exception.Matches(finding) {
+				used[i], suppressed = true, true
+			}
+		}
+		return suppressed
+	})
+
+	for i, exception := range exceptions {
+		if used[i] {
+			continue
+		}
+
+		finding := newFinding(RuleStaleException, exception.Glob, fmt.Sprintf("exception matches no finding: %s", exception))
+		finding.Exception = &Location{File: exceptionsPath, Line: exception.Line}
+		findings = append(findings, finding)
+	}
+
+	return findings
+}
+
+// WriteExceptions updates the exceptions file to suppress exactly the
+// findings: the exceptions that match no finding are removed, and an
+// exception is added for every finding that no exception suppresses. The
+// comments and the other exceptions of the file are kept. It returns the
+// number of added and removed exceptions.
+func WriteExceptions(exceptionsPath string, findings []Finding) (int, int, error) {
+	var lines []string
+	mode := os.FileMode(0644)
+	contents, err := os.ReadFile(exceptionsPath)
+	switch {
+	case err == nil:
+		if text := strings.TrimRight(string(contents), "\n"); text != "" {
+			lines = strings.Split(text, "\n")
+		}
+		if info, err := os.Stat(exceptionsPath); err == nil {
+			mode = info.Mode().Perm()
+		}
+	case os.IsNotExist(err):
+		lines = []string{`# Exceptions to the helm-tool lint rules, as "<rule>: <path glob>"`}
+	default:
+		return 0, 0, err
+	}
+
+	exceptions, err := ParseExceptions(string(contents))
+	if err != nil {
+		return 0, 0, fmt.Errorf("%s: %w", exceptionsPath, err)
+	}
+
+	var kept []Exception
+	stale := map[int]bool{}
+	for _, exception := range exceptions {
+		if slices.ContainsFunc(findings, exception.Matches) {
+			kept = append(kept, exception)
+		} else {
+			stale[exception.Line] = true
+		}
+	}
+
+	var result []string
+	for i, line := range lines {
+		if !stale[i+1] {
+			result = append(result, line)
+		}
+	}
+
+	added := 0
+	for _, finding := range findings {
+		suppressed := slices.ContainsFunc(kept, func(exception Exception) bool {
+			return exception.Matches(finding)
+		})
+		if suppressed || finding.Rule == RuleStaleException {
+			continue
+		}
+
+		exception, err := parseException(finding.Rule + ": " + finding.Path)
+		if err != nil {
+			return 0, 0, err
+		}
+
+		kept = append(kept, exception)
+		result = append(result, exception.String())
+		added++
+	}
+
+	if err := os.WriteFile(exceptionsPath, []byte(strings.Join(result, "\n")+"\n"), mode); err != nil {
+		return 0, 0, err
+	}
+
+	return added, len(stale), nil
+}
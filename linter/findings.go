@@ -0,0 +1,184 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package linter
+
+import (
+	"cmp"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/cert-manager/helm-tool/linter/parsetemplates"
+)
+
+// The rules checked by the linter.
+const (
+	// RuleUndefined reports values used by the templates that are missing
+	// from values.yaml.
+	RuleUndefined = "undefined"
+	// RuleUnused reports values of values.yaml that no template uses.
+	RuleUnused = "unused"
+	// RuleSeeTarget reports +docs:see tags whose target is not a documented
+	// property, as the documentation cannot link to it.
+	RuleSeeTarget = "see-target"
+	// RuleStaleException reports exceptions that match no finding, they can
+	// be removed from the exceptions file.
+	RuleStaleException = "stale-exception"
+)
+
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+type rule struct {
+	id          string
+	description string
+	severity    Severity
+}
+
+// rules describes the rules, in the order findings are reported.
+var rules = []rule{
+	{RuleUndefined, "Value used by the templates is missing from values.yaml", SeverityError},
+	{RuleUnused, "Value of values.yaml is not used by the templates", SeverityError},
+	{RuleSeeTarget, "Target of a +docs:see tag is not a documented property", SeverityWarning},
+	{RuleStaleException, "Exception matches no finding", SeverityError},
+}
+
+// Location is a line of a file, the line is 0 if unknown.
+type Location struct {
+	File string `json:"file"`
+	Line int    `json:"line,omitempty"`
+	// Chain lists the include and template calls leading to a location in a
+	// named template, starting with the call in the template file.
+	Chain []Location `json:"chain,omitempty"`
+}
+
+func (l Location) String() string {
+	location := l.File
+	if l.Line != 0 {
+		location = fmt.Sprintf("%s:%d", l.File, l.Line)
+	}
+
+	if len(l.Chain) > 0 {
+		var calls []string
+		for _, call := range l.Chain {
+			calls = append(calls, call.String())
+		}
+		location += " (via " + strings.Join(calls, ", ") + ")"
+	}
+
+	return location
+}
+
+// Finding is a problem reported by the linter.
+type Finding struct {
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	// Path is the value path the finding is about.
+	Path    string `json:"path"`
+	Message string `json:"message"`
+	// Values is where values.yaml defines the path, or the property
+	// referencing it for see-target findings.
+	Values *Location `json:"values,omitempty"`
+	// Templates lists where the templates use the path.
+	Templates []Location `json:"templates,omitempty"`
+	// Exception is the line of the exceptions file, for stale-exception
+	// findings.
+	Exception *Location `json:"exception,omitempty"`
+}
+
+// Location returns the most relevant location of the finding, the first
+// template using the path, the line of values.yaml or of the exceptions file.
+func (f Finding) Location() (Location, bool) {
+	if len(f.Templates) > 0 {
+		return f.Templates[0], true
+	}
+
+	if f.Values != nil {
+		return *f.Values, true
+	}
+
+	if f.Exception != nil {
+		return *f.Exception, true
+	}
+
+	return Location{}, false
+}
+
+// CountErrors returns the number of findings with the error severity, which
+// fail the lint. Warnings are only reported.
+func CountErrors(findings []Finding) int {
+	count := 0
+	for _, finding := range findings {
+		if finding.Severity == SeverityError {
+			count++
+		}
+	}
+
+	return count
+}
+
+// newFinding returns a finding of the rule, with the severity of the rule.
+func newFinding(ruleID string, path string, message string) Finding {
+	finding := Finding{Rule: ruleID, Path: path, Message: message}
+	for _, r := range rules {
+		if r.id == ruleID {
+			finding.Severity = r.severity
+		}
+	}
+
+	return finding
+}
+
+// templateLocations returns the locations of the template usages, with the
+// template names relative to the templates folder.
+func templateLocations(templatesFolder string, usages []parsetemplates.Usage) []Location {
+	location := func(position parsetemplates.Position) Location {
+		return Location{
+			File: filepath.Join(templatesFolder, filepath.FromSlash(position.Template)),
+			Line: position.Line,
+		}
+	}
+
+	var locations []Location
+	for _, usage := range usages {
+		usageLocation := location(usage.Position)
+		for _, call := range usage.Chain {
+			usageLocation.Chain = append(usageLocation.Chain, location(call))
+		}
+		locations = append(locations, usageLocation)
+	}
+
+	return locations
+}
+
+// sortFindings orders the findings by rule and path.
+func sortFindings(findings []Finding) {
+	ruleIndex := func(id string) int {
+		return slices.IndexFunc(rules, func(r rule) bool {
+			return r.id == id
+		})
+	}
+
+	slices.SortStableFunc(findings, func(a, b Finding) int {
+		return cmp.Or(cmp.Compare(ruleIndex(a.Rule), ruleIndex(b.Rule)), strings.Compare(a.Path, b.Path))
+	})
+}
@@ -18,67 +18,116 @@ package linter
 
 import (
 	"fmt"
-	"os"
-	"slices"
-	"strings"
 
 	"github.com/cert-manager/helm-tool/linter/parsetemplates"
 	"github.com/cert-manager/helm-tool/linter/sets"
 	"github.com/cert-manager/helm-tool/parser"
 )
 
+// Lint compares the values documented in values.yaml with the values used by
+// the templates, and returns the findings that are not suppressed by the
+// exceptions file, if any.
 func Lint(
 	templatesFolder string,
 	exceptionsPath string,
+	valuesFile string,
 	document *parser.Document,
-) error {
-	templatePaths, err := parsetemplates.ListTemplatePaths(templatesFolder)
+) ([]Finding, error) {
+	templateUsages, err := parsetemplates.ListTemplateUsages(templatesFolder)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	templatePaths := templateUsages.Paths()
+
 	valuePaths := sets.Set[string]{}
+	valueLines := map[string]int{}
 	for _, section := range document.Sections {
 		for _, property := range section.Properties {
-			valuePaths.Insert(property.Path.PatternString())
+			path := property.Path.PatternString()
+			valuePaths.Insert(path)
+			if valueLines[path] == 0 {
+				valueLines[path] = property.Line
+			}
 		}
 	}
 	valuePaths = sets.RemovePrefixes(valuePaths)
 
-	exceptionStrings := []string{}
+	var exceptions []Exception
 	if exceptionsPath != "" {
-		exceptionsPathsRaw, err := os.ReadFile(exceptionsPath)
+		exceptions, err = LoadExceptions(exceptionsPath)
 		if err != nil {
-			return err
+			return nil, err
 		}
-
-		exceptionStrings = strings.Split(string(exceptionsPathsRaw), "\n")
 	}
 
 	missingValues, missingTemplates := DiffPaths(valuePaths, templatePaths)
 
-	succeeded := true
+	var findings []Finding
 	for missingValue := range missingValues {
-		exceptionString := fmt.Sprintf("value missing from values.yaml: %s", missingValue)
+		finding := newFinding(RuleUndefined, missingValue, fmt.Sprintf("value missing from values.yaml: %s", missingValue))
+		finding.Templates = templateLocations(templatesFolder, templateUsages[missingValue])
+		findings = append(findings, finding)
+	}
 
-		if !slices.Contains(exceptionStrings, exceptionString) {
-			fmt.Println(exceptionString)
-			succeeded = false
+	// Any value below a path read with a dynamic key can be used
+	dynamicPaths := templateUsages.DynamicPaths()
+	for missingTemplate := range missingTemplates {
+		if underDynamicPath(missingTemplate, dynamicPaths) {
+			continue
 		}
+
+		finding := newFinding(RuleUnused, missingTemplate, fmt.Sprintf("value missing from templates: %s", missingTemplate))
+		finding.Values = &Location{File: valuesFile, Line: valueLines[missingTemplate]}
+		findings = append(findings, finding)
 	}
 
-	for missingTemplate := range missingTemplates {
-		exceptionString := fmt.Sprintf("value missing from templates: %s", missingTemplate)
+	for _, finding := range undocumentedSeeTargets(document) {
+		finding.Values.File = valuesFile
+		findings = append(findings, finding)
+	}
+
+	findings = applyExceptions(findings, exceptions, exceptionsPath)
+	sortFindings(findings)
+
+	return findings, nil
+}
 
-		if !slices.Contains(exceptionStrings, exceptionString) {
-			fmt.Println(exceptionString)
-			succeeded = false
+// underDynamicPath reports whether the path is one of the dynamic paths, or
+// a value below one of them.
+func underDynamicPath(path string, dynamicPaths sets.Set[string]) bool {
+	for prefix := range dynamicPaths {
+		if path == prefix || sets.HasPathPrefix(path, prefix) {
+			return true
 		}
 	}
 
-	if !succeeded {
-		return fmt.Errorf("values.yaml and templates are not in sync")
+	return false
+}
+
+// undocumentedSeeTargets returns a finding for every +docs:see tag whose target
+// is not a documented property, as the documentation cannot link to it. The
+// file of the values locations is left empty.
+func undocumentedSeeTargets(document *parser.Document) []Finding {
+	documented := sets.Set[string]{}
+	for _, section := range document.Sections {
+		for _, property := range section.Properties {
+			documented.Insert(property.Path.String())
+		}
+	}
+
+	var findings []Finding
+	for _, section := range document.Sections {
+		for _, property := range section.Properties {
+			for _, target := range property.See {
+				if !documented.Has(target.String()) {
+					finding := newFinding(RuleSeeTarget, target.String(), fmt.Sprintf("see target missing from values.yaml: %s (referenced by %s)", target, property.Path))
+					finding.Values = &Location{Line: property.Line}
+					findings = append(findings, finding)
+				}
+			}
+		}
 	}
 
-	return nil
+	return findings
 }
@@ -0,0 +1,270 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package linter
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/helm-tool/parser"
+)
+
+func TestUndocumentedSeeTargets(t *testing.T) {
+	valuesPath := filepath.Join(t.TempDir(), "values.yaml")
+	require.NoError(t, os.WriteFile(valuesPath, []byte(`
+webhook:
+  # +docs:see=webhook.url
+  # +docs:see=webhook
+  # +docs:see=webhook.missing
+  timeoutSeconds: 30
+  url: ""
+`), 0600))
+
+	document, err := parser.Load(valuesPath, true)
+	require.NoError(t, err)
+
+	findings := undocumentedSeeTargets(document)
+	require.Len(t, findings, 2)
+	assert.Equal(t, "see target missing from values.yaml: webhook (referenced by webhook.timeoutSeconds)", findings[0].Message)
+	assert.Equal(t, "see target missing from values.yaml: webhook.missing (referenced by webhook.timeoutSeconds)", findings[1].Message)
+	assert.Equal(t, RuleSeeTarget, findings[1].Rule)
+	assert.Equal(t, 6, findings[1].Values.Line)
+}
+
+func TestLint(t *testing.T) {
+	dir := t.TempDir()
+	valuesPath := filepath.Join(dir, "values.yaml")
+	templatesPath := filepath.Join(dir, "templates")
+	exceptionsPath := filepath.Join(dir, "exceptions")
+	require.NoError(t, os.MkdirAll(templatesPath, 0755))
+	require.NoError(t, os.WriteFile(valuesPath, []byte(`
+image:
+  tag: v1
+unused: 1
+excepted: 1
+`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(templatesPath, "deployment.yaml"), []byte(`
+image: {{ .Values.image.tag }}
+replicas: {{ .Values.replicas }}
+`), 0600))
+	require.NoError(t, os.WriteFile(exceptionsPath, []byte("value missing from templates: excepted\n"), 0600))
+
+	document, err := parser.Load(valuesPath, true)
+	require.NoError(t, err)
+
+	findings, err := Lint(templatesPath, exceptionsPath, valuesPath, document)
+	require.NoError(t, err)
+	assert.Equal(t, []Finding{
+		{
+			Rule:      RuleUndefined,
+			Severity:  SeverityError,
+			Path:      "replicas",
+			Message:   "value missing from values.yaml: replicas",
+			Templates: []Location{{File: filepath.Join(templatesPath, "deployment.yaml"), Line: 3}},
+		},
+		{
+			Rule:     RuleUnused,
+			Severity: SeverityError,
+			Path:     "unused",
+			Message:  "value missing from templates: unused",
+			Values:   &Location{File: valuesPath, Line: 4},
+		},
+	}, findings)
+
+	// Undefined and unused values both fail the lint
+	assert.Equal(t, 2, CountErrors(findings))
+}
+
+func TestLint_DynamicKeys(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		unused   []string
+	}{
+		{
+			name:     "root",
+			template: "{{ index .Values $key }}{{ .Values.a }}",
+		},
+		{
+			name:     "object",
+			template: "{{ index .Values.c $key }}{{ .Values.c.d }}",
+			unused:   []string{"a", "b"},
+		},
+		{
+			name:     "literal keys",
+			template: "{{ index .Values \"c\" \"d\" }}{{ .Values.a }}",
+			unused:   []string{"b", "c.e"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			valuesPath := filepath.Join(dir, "values.yaml")
+			templatesPath := filepath.Join(dir, "templates")
+			require.NoError(t, os.MkdirAll(templatesPath, 0755))
+			require.NoError(t, os.WriteFile(valuesPath, []byte("a: 1\nb: 2\nc:\n  d: 3\n  e: 4\n"), 0600))
+			require.NoError(t, os.WriteFile(filepath.Join(templatesPath, "a.yaml"), []byte("{{ $key := \"b\" }}"+tt.template), 0600))
+
+			document, err := parser.Load(valuesPath, true)
+			require.NoError(t, err)
+
+			findings, err := Lint(templatesPath, "", valuesPath, document)
+			require.NoError(t, err)
+
+			var unused []string
+			for _, finding := range findings {
+				require.Equal(t, RuleUnused, finding.Rule, finding.Message)
+				unused = append(unused, finding.Path)
+			}
+			assert.Equal(t, tt.unused, unused)
+		})
+	}
+}
+
+func TestLint_Exceptions(t *testing.T) {
+	dir := t.TempDir()
+	valuesPath := filepath.Join(dir, "values.yaml")
+	templatesPath := filepath.Join(dir, "templates")
+	exceptionsPath := filepath.Join(dir, "exceptions")
+	require.NoError(t, os.MkdirAll(templatesPath, 0755))
+	require.NoError(t, os.WriteFile(valuesPath, []byte(`
+webhook:
+  podLabels: {}
+cainjector:
+  podLabels: {}
+unused: 1
+`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(templatesPath, "deployment.yaml"), []byte(`
+name: {{ .Values.global.name }}
+`), 0600))
+	require.NoError(t, os.WriteFile(exceptionsPath, []byte(`# labels are rendered with tpl
+unused: *.podLabels
+
+undefined: global.**
+unused: removed
+`), 0600))
+
+	document, err := parser.Load(valuesPath, true)
+	require.NoError(t, err)
+
+	findings, err := Lint(templatesPath, exceptionsPath, valuesPath, document)
+	require.NoError(t, err)
+	require.Len(t, findings, 2)
+	assert.Equal(t, "unused", findings[0].Path)
+	assert.Equal(t, Finding{
+		Rule:      RuleStaleException,
+		Severity:  SeverityError,
+		Path:      "removed",
+		Message:   "exception matches no finding: unused: removed",
+		Exception: &Location{File: exceptionsPath, Line: 5},
+	}, findings[1])
+
+	findings, err = Lint(templatesPath, "", valuesPath, document)
+	require.NoError(t, err)
+	added, removed, err := WriteExceptions(exceptionsPath, findings)
+	require.NoError(t, err)
+	assert.Equal(t, 1, added)
+	assert.Equal(t, 1, removed)
+
+	exceptions, err := os.ReadFile(exceptionsPath)
+	require.NoError(t, err)
+	assert.Equal(t, `# labels are rendered with tpl
+unused: *.podLabels
+
+undefined: global.**
+unused: unused
+`, string(exceptions))
+
+	findings, err = Lint(templatesPath, exceptionsPath, valuesPath, document)
+	require.NoError(t, err)
+	assert.Empty(t, findings)
+}
+
+func TestParseExceptions(t *testing.T) {
+	exceptions, err := ParseExceptions(`value missing from values.yaml: image.tag
+see target missing from values.yaml: webhook (referenced by webhook.url)
+`)
+	require.NoError(t, err)
+	require.Len(t, exceptions, 2)
+	assert.Equal(t, "undefined: image.tag", exceptions[0].String())
+	assert.Equal(t, "see-target: webhook", exceptions[1].String())
+	assert.Equal(t, 2, exceptions[1].Line)
+
+	_, err = ParseExceptions("\nunknown: image")
+	assert.ErrorContains(t, err, `line 2: unknown rule "unknown"`)
+
+	_, err = ParseExceptions("image.tag")
+	assert.ErrorContains(t, err, "expected \"<rule>: <path glob>\"")
+}
+
+func TestReport(t *testing.T) {
+	findings := []Finding{
+		{
+			Rule:      RuleUndefined,
+			Severity:  SeverityError,
+			Path:      "replicas",
+			Message:   "value missing from values.yaml: replicas",
+			Templates: []Location{{File: "templates/deployment.yaml", Line: 3}},
+		},
+		{
+			Rule:     RuleUnused,
+			Severity: SeverityWarning,
+			Path:     "podLabels",
+			Message:  "value missing from templates: podLabels, 100%",
+			Values:   &Location{File: "values.yaml"},
+		},
+	}
+
+	text, err := Report(findings, FormatText)
+	require.NoError(t, err)
+	assert.Equal(t, "templates/deployment.yaml:3: value missing from values.yaml: replicas\n"+
+		"values.yaml: value missing from templates: podLabels, 100%", text)
+
+	github, err := Report(findings, FormatGitHub)
+	require.NoError(t, err)
+	assert.Equal(t, "::error file=templates/deployment.yaml,line=3,title=helm-tool undefined::value missing from values.yaml: replicas\n"+
+		"::warning file=values.yaml,title=helm-tool unused::value missing from templates: podLabels, 100%25", github)
+
+	sarifOutput, err := Report(findings, FormatSARIF)
+	require.NoError(t, err)
+	var log sarif
+	require.NoError(t, json.Unmarshal([]byte(sarifOutput), &log))
+	require.Len(t, log.Runs, 1)
+	assert.Len(t, log.Runs[0].Tool.Driver.Rules, 4)
+	require.Len(t, log.Runs[0].Results, 2)
+	assert.Equal(t, "undefined", log.Runs[0].Results[0].RuleID)
+	assert.Equal(t, SeverityWarning, log.Runs[0].Results[1].Level)
+	assert.Equal(t, "templates/deployment.yaml", log.Runs[0].Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, 3, log.Runs[0].Results[0].Locations[0].PhysicalLocation.Region.StartLine)
+	assert.Nil(t, log.Runs[0].Results[1].Locations[0].PhysicalLocation.Region)
+
+	included := Location{File: "templates/_helpers.tpl", Line: 3, Chain: []Location{{File: "templates/deployment.yaml", Line: 2}}}
+	assert.Equal(t, "templates/_helpers.tpl:3 (via templates/deployment.yaml:2)", included.String())
+
+	empty, err := Report(nil, FormatJSON)
+	require.NoError(t, err)
+	assert.Equal(t, "[]", empty)
+
+	_, err = Report(findings, "xml")
+	require.ErrorContains(t, err, "unknown format")
+}
@@ -17,10 +17,13 @@ limitations under the License.
 package parsetemplates
 
 import (
+	"cmp"
 	"fmt"
 	"io/fs"
 	"os"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 	"text/template"
 	"text/template/parse"
@@ -29,7 +32,79 @@ import (
 	"github.com/cert-manager/helm-tool/linter/sets"
 )
 
+// Position is a location in a template file.
+type Position struct {
+	// Template is the name of the template file, relative to the templates
+	// folder.
+	Template string
+	Line     int
+	Column   int
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%s:%d:%d", p.Template, p.Line, p.Column)
+}
+
+// Compare orders positions by template, line and column.
+func (p Position) Compare(other Position) int {
+	return cmp.Or(
+		strings.Compare(p.Template, other.Template),
+		cmp.Compare(p.Line, other.Line),
+		cmp.Compare(p.Column, other.Column),
+	)
+}
+
+// Usage is a place a value is used at.
+type Usage struct {
+	Position
+	// Chain lists the include and template calls that led to the usage,
+	// starting with the call in the template file. It is empty for values
+	// used directly by a template file.
+	Chain []Position
+	// Dynamic is set if the usage reads a key of the path that is not a
+	// literal, so any value below the path can be used.
+	Dynamic bool
+}
+
+// Usages maps the value paths used by the templates to their usages, ordered
+// by position.
+type Usages map[string][]Usage
+
+// Paths returns the value paths used by the templates.
+func (u Usages) Paths() sets.Set[string] {
+	paths := sets.Set[string]{}
+	for path := range u {
+		paths.Insert(path)
+	}
+
+	return paths
+}
+
+// DynamicPaths returns the value paths read with a dynamic key, any value
+// below them can be used by the templates.
+func (u Usages) DynamicPaths() sets.Set[string] {
+	paths := sets.Set[string]{}
+	for path, usages := range u {
+		if slices.ContainsFunc(usages, func(usage Usage) bool { return usage.Dynamic }) {
+			paths.Insert(path)
+		}
+	}
+
+	return paths
+}
+
 func ListTemplatePaths(templatesPath string) (sets.Set[string], error) {
+	usages, err := ListTemplateUsages(templatesPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return usages.Paths(), nil
+}
+
+// ListTemplateUsages returns the value paths used by the templates, with all
+// the places they are used at.
+func ListTemplateUsages(templatesPath string) (Usages, error) {
 	tmpl := template.New("ROOT")
 
 	tmpl.Funcs(funcs_serdes.FuncMap())
@@ -62,13 +137,18 @@ func ListTemplatePaths(templatesPath string) (sets.Set[string], error) {
 		return nil, err
 	}
 
-	return ListTemplatePathsFromTemplates(tmpl, templates)
+	return ListTemplateUsagesFromTemplates(tmpl, templates)
 }
 
 func joinPath(path string, segments ...string) string {
 	joint := strings.Join(segments, ".")
 	joint = strings.TrimLeft(joint, ".")
-	path = fmt.Sprintf("%s.%s", path, joint)
+	if strings.HasPrefix(joint, "[") {
+		// quoted keys and array items are not separated by a dot
+		path = path + joint
+	} else {
+		path = fmt.Sprintf("%s.%s", path, joint)
+	}
 	path = strings.TrimRight(path, ".")
 	return path
 }
@@ -90,6 +170,9 @@ type templateUsage struct {
 const (
 	RootNode = "<root-node>"
 	RootPath = "<root-path>"
+	// DynamicKey is the path segment of a key that is not a literal, eg.
+	// index .Values $key.
+	DynamicKey = "[<dynamic>]"
 
 	// maxFollowPathVisits caps followPath's total node visits. It enumerates
 	// every acyclic path, so an attacker-supplied width-2 lattice of depth k has
@@ -102,10 +185,36 @@ func ListTemplatePathsFromTemplates(
 	tmpl *template.Template,
 	templates sets.Set[*template.Template],
 ) (sets.Set[string], error) {
+	usages, err := ListTemplateUsagesFromTemplates(tmpl, templates)
+	if err != nil {
+		return nil, err
+	}
+
+	return usages.Paths(), nil
+}
+
+func ListTemplateUsagesFromTemplates(
+	tmpl *template.Template,
+	templates sets.Set[*template.Template],
+) (Usages, error) {
 	// templateResults lists all property paths that are used in a template
 	templateResults := map[string]sets.Set[string]{}
+	// templatePositions lists the positions of the paths used in a template
+	templatePositions := map[string]map[string]sets.Set[Position]{}
 	// templateUsage lists all templates that are used in a template
 	templateUsages := map[string]sets.Set[templateUsage]{}
+	// callPositions records where a template is called from, the first call
+	// is kept when a template is called several times with the same context
+	callPositions := map[string]map[templateUsage]Position{}
+
+	found := func(node, path string, position Position) {
+		getSet(templateResults, node).Insert(path)
+		if templatePositions[node] == nil {
+			templatePositions[node] = map[string]sets.Set[Position]{}
+		}
+		getSet(templatePositions[node], path).Insert(position)
+	}
+
 	for _, t := range tmpl.Templates() {
 		var selfNode, selfPath string
 		if _, ok := templates[t]; ok {
@@ -120,21 +229,30 @@ func ListTemplatePathsFromTemplates(
 			selfPath = ""
 		}
 
-		results := getSet(templateResults, selfNode)
+		getSet(templateResults, selfNode)
 		usages := getSet(templateUsages, selfNode)
 
 		walk(t.Root, selfNode, selfPath,
 			// Found path to a value
-			func(path string) {
-				results.Insert(path)
+			func(path string, node parse.Node) {
+				found(selfNode, path, positionOf(t.Tree, node))
 			},
 			// Found template call
-			func(templateName string, context string) {
-				usages.Insert(templateUsage{templateName, context})
+			func(templateName string, context string, node parse.Node) {
+				usage := templateUsage{templateName, context}
+				usages.Insert(usage)
+
+				if callPositions[selfNode] == nil {
+					callPositions[selfNode] = map[templateUsage]Position{}
+				}
+				position := positionOf(t.Tree, node)
+				if previous, ok := callPositions[selfNode][usage]; !ok || position.Compare(previous) < 0 {
+					callPositions[selfNode][usage] = position
+				}
 			},
 			// Found local variable usage
-			func(varname string, path string) {
-				getSet(templateResults, joinPath(selfNode, varname)).Insert(path)
+			func(varname string, path string, node parse.Node) {
+				found(joinPath(selfNode, varname), path, positionOf(t.Tree, node))
 			},
 			// Found local variable definition
 			func(varname string, node, path string) {
@@ -143,13 +261,24 @@ func ListTemplatePathsFromTemplates(
 		)
 	}
 
+	// rootUsages lists the paths used by the template files, with the
+	// preferred call chain to every position they are used at
+	rootUsages := map[string]map[Position][]Position{}
 	budget := maxFollowPathVisits
-	completed := followPath(RootNode, sets.Set[string]{}, &budget, templateUsages, func(node, path string) {
+	completed := followPath(RootNode, nil, sets.Set[string]{}, &budget, templateUsages, callPositions, func(node, path string, chain []Position) {
 		for key := range templateResults[node] {
-			if strings.HasPrefix(key, RootPath) {
-				templateResults[RootNode].Insert(key)
-			} else {
-				templateResults[RootNode].Insert(joinPath(path, key))
+			resolved := key
+			if !strings.HasPrefix(key, RootPath) {
+				resolved = joinPath(path, key)
+			}
+
+			if rootUsages[resolved] == nil {
+				rootUsages[resolved] = map[Position][]Position{}
+			}
+			for position := range templatePositions[node][key] {
+				if previous, ok := rootUsages[resolved][position]; !ok || compareChains(chain, previous) < 0 {
+					rootUsages[resolved][position] = chain
+				}
 			}
 		}
 	})
@@ -162,27 +291,122 @@ func ListTemplatePathsFromTemplates(
 		)
 	}
 
-	paths := sets.Set[string]{}
-	for key := range templateResults[RootNode] {
-		if !strings.HasPrefix(key, joinPath(RootPath, "Values")+".") {
+	// .Values itself is the empty path, and quoted keys are not separated by a
+	// dot: .Values["a.b"]. The paths read with a dynamic key are kept apart,
+	// any value below them can be used.
+	valuesPath := joinPath(RootPath, "Values")
+	valueUsages := map[string]map[Position][]Position{}
+	dynamicUsages := map[string]map[Position][]Position{}
+	for key, positions := range rootUsages {
+		if key != valuesPath && !sets.HasPathPrefix(key, valuesPath) {
 			continue
 		}
-		paths.Insert(strings.TrimPrefix(key, joinPath(RootPath, "Values")+"."))
+
+		path := strings.TrimPrefix(strings.TrimPrefix(key, valuesPath), ".")
+		target := valueUsages
+		if prefix, _, ok := strings.Cut(path, DynamicKey); ok {
+			path, target = prefix, dynamicUsages
+		}
+
+		if target[path] == nil {
+			target[path] = map[Position][]Position{}
+		}
+		mergePositions(target[path], positions)
 	}
 
-	return sets.RemovePrefixes(paths), nil
+	paths := sets.Set[string]{}
+	for path := range valueUsages {
+		paths.Insert(path)
+	}
+
+	usages := Usages{}
+	for path := range sets.RemovePrefixes(paths) {
+		// The objects containing the path are not reported, their usages
+		// (eg. toYaml .Values.foo when .Values.foo.bar is used as well) are
+		// usages of the path
+		positions := map[Position][]Position{}
+		for prefix := range paths {
+			if prefix == path || sets.HasPathPrefix(path, prefix) {
+				mergePositions(positions, valueUsages[prefix])
+			}
+		}
+
+		for position, chain := range positions {
+			usages[path] = append(usages[path], Usage{Position: position, Chain: chain})
+		}
+	}
+
+	// Paths read with a dynamic key are reported even if other values below
+	// them are used as well
+	for path, positions := range dynamicUsages {
+		for position, chain := range positions {
+			usages[path] = append(usages[path], Usage{Position: position, Chain: chain, Dynamic: true})
+		}
+	}
+
+	for _, pathUsages := range usages {
+		slices.SortFunc(pathUsages, func(a, b Usage) int {
+			return cmp.Or(a.Position.Compare(b.Position), compareBools(a.Dynamic, b.Dynamic))
+		})
+	}
+
+	return usages, nil
+}
+
+// mergePositions adds the positions to target, keeping the preferred call
+// chain of positions found in both.
+func mergePositions(target, positions map[Position][]Position) {
+	for position, chain := range positions {
+		if previous, ok := target[position]; !ok || compareChains(chain, previous) < 0 {
+			target[position] = chain
+		}
+	}
+}
+
+// compareBools orders false before true.
+func compareBools(a, b bool) int {
+	switch {
+	case a == b:
+		return 0
+	case a:
+		return 1
+	default:
+		return -1
+	}
+}
+
+// compareChains prefers the shortest call chain, then the first one.
+func compareChains(a, b []Position) int {
+	return cmp.Or(cmp.Compare(len(a), len(b)), slices.CompareFunc(a, b, Position.Compare))
+}
+
+// positionOf returns the position of the node in the file it was parsed from.
+func positionOf(tree *parse.Tree, node parse.Node) Position {
+	// The location is formatted as "file:line:column"
+	location, _ := tree.ErrorContext(node)
+	parts := strings.Split(location, ":")
+	if len(parts) < 3 {
+		return Position{Template: location}
+	}
+
+	line, _ := strconv.Atoi(parts[len(parts)-2])
+	column, _ := strconv.Atoi(parts[len(parts)-1])
+	return Position{Template: strings.Join(parts[:len(parts)-2], ":"), Line: line, Column: column}
 }
 
 // followPath walks the template reference graph from node, invoking run for
-// every reachable node. budget is shared across the recursion and decremented
-// per visit; it returns false (incomplete, results must be discarded) if the
-// walk was aborted on budget exhaustion, true otherwise.
+// every reachable node with the calls that led to it, appended to chain.
+// budget is shared across the recursion and decremented per visit; it returns
+// false (incomplete, results must be discarded) if the walk was aborted on
+// budget exhaustion, true otherwise.
 func followPath(
 	node string,
+	chain []Position,
 	visited sets.Set[string],
 	budget *int,
 	templateUsage map[string]sets.Set[templateUsage],
-	run func(node string, path string),
+	callPositions map[string]map[templateUsage]Position,
+	run func(node string, path string, chain []Position),
 ) bool {
 	if *budget <= 0 {
 		return false
@@ -197,14 +421,20 @@ func followPath(
 	completed := true
 	for usage := range templateUsage[node] {
 		// Recursively follow the path until we reach the <root-node>
-		if !followPath(usage.node, visited, budget, templateUsage, func(node, path string) {
-			run(node, joinPath(usage.context, path))
+		// Variable definitions are not calls, they have no position
+		usageChain := chain
+		if call, ok := callPositions[node][usage]; ok {
+			usageChain = append(chain[:len(chain):len(chain)], call)
+		}
+
+		if !followPath(usage.node, usageChain, visited, budget, templateUsage, callPositions, func(node, path string, chain []Position) {
+			run(node, joinPath(usage.context, path), chain)
 		}) {
 			completed = false
 			break
 		}
 	}
-	run(node, "")
+	run(node, "", chain)
 
 	visited.Delete(node)
 
@@ -216,11 +446,11 @@ func walk(
 	parentNode string,
 	parentPath string,
 	// foundPathFn is called when a used path is found
-	foundPathFn func(path string),
+	foundPathFn func(path string, node parse.Node),
 	// foundTemplateFn is called when a template-like call is found
-	foundTemplateFn func(templateName string, context string),
+	foundTemplateFn func(templateName string, context string, node parse.Node),
 	// foundVarUsageFn is called when a variable is used
-	foundVarUsageFn func(varname string, path string),
+	foundVarUsageFn func(varname string, path string, node parse.Node),
 	// foundVarDefFn is called when a variable is defined
 	foundVarDefFn func(varname string, node, path string),
 ) {
@@ -230,23 +460,23 @@ func walk(
 
 	switch tn := node.(type) {
 	case *parse.DotNode:
-		foundPathFn(parentPath)
+		foundPathFn(parentPath, tn)
 	case *parse.FieldNode:
 		if len(tn.Ident) == 0 {
 		} else if tn.Ident[0] == "$" {
-			foundPathFn(joinPath(RootPath, tn.Ident[1:]...))
+			foundPathFn(joinPath(RootPath, tn.Ident[1:]...), tn)
 		} else {
-			foundPathFn(joinPath(parentPath, tn.Ident...))
+			foundPathFn(joinPath(parentPath, tn.Ident...), tn)
 		}
 		return
 	case *parse.VariableNode:
 		if len(tn.Ident) == 0 {
 		} else if tn.Ident[0] == "$" {
-			foundPathFn(joinPath(RootPath, tn.Ident[1:]...))
+			foundPathFn(joinPath(RootPath, tn.Ident[1:]...), tn)
 		} else if len(tn.Ident[0]) >= 1 && tn.Ident[0][0] == '$' {
-			foundVarUsageFn(tn.Ident[0], joinPath("", tn.Ident[1:]...))
+			foundVarUsageFn(tn.Ident[0], joinPath("", tn.Ident[1:]...), tn)
 		} else {
-			foundPathFn(joinPath(parentPath, tn.Ident...))
+			foundPathFn(joinPath(parentPath, tn.Ident...), tn)
 		}
 		return
 	}
@@ -257,12 +487,38 @@ func walk(
 	case *parse.ChainNode:
 		walk(tn.Node, parentNode, parentPath, foundPathFn, foundTemplateFn, foundVarUsageFn, foundVarDefFn)
 	case *parse.CommandNode:
+		// handle 'index .Values "foo-bar"' and the similar sprig functions
+		// like dotted access to the keys
+		if access, ok := parseValueAccess(tn); ok {
+			for _, dict := range access.dicts {
+				walk(dict, parentNode, parentPath,
+					func(path string, node parse.Node) {
+						foundPathFn(path+access.suffix, node)
+					},
+					func(templateName, context string, node parse.Node) {
+						foundTemplateFn(templateName, context+access.suffix, node)
+					},
+					func(varname string, path string, node parse.Node) {
+						foundVarUsageFn(varname, path+access.suffix, node)
+					},
+					func(varname string, node, path string) {
+						foundVarDefFn(varname, node, path+access.suffix)
+					},
+				)
+			}
+			for _, snode := range access.others {
+				walk(snode, parentNode, parentPath, foundPathFn, foundTemplateFn, foundVarUsageFn, foundVarDefFn)
+			}
+			return
+		}
+
 		// handle 'include "test.labels" .' separately
 		if len(tn.Args) >= 3 && tn.Args[0].String() == "include" && tn.Args[1].Type() == parse.NodeString {
 			foreachPath(tn.Args[2], parentNode, parentPath, func(path string) {
 				foundTemplateFn(
 					tn.Args[1].(*parse.StringNode).Text,
 					path,
+					tn,
 				)
 			})
 		}
@@ -285,13 +541,13 @@ func walk(
 		for _, decl := range tn.Decl {
 			for _, cmd := range tn.Cmds {
 				walk(cmd, parentNode, parentPath,
-					func(path string) {
+					func(path string, _ parse.Node) {
 						foundVarDefFn(decl.String(), parentNode, path)
 					},
-					func(templateName, context string) {
+					func(templateName, context string, _ parse.Node) {
 						foundVarDefFn(decl.String(), templateName, context)
 					},
-					func(varname string, path string) {
+					func(varname string, path string, _ parse.Node) {
 						foundVarDefFn(decl.String(), joinPath(parentNode, varname), path)
 					},
 					func(varname string, node, path string) {
@@ -303,25 +559,25 @@ func walk(
 		}
 	case *parse.TemplateNode:
 		walk(tn.Pipe, parentNode, parentPath,
-			func(path string) {
-				foundTemplateFn(tn.Name, path)
+			func(path string, _ parse.Node) {
+				foundTemplateFn(tn.Name, path, tn)
 			},
-			func(templateName, context string) {},
-			func(varname string, path string) {},
+			func(templateName, context string, _ parse.Node) {},
+			func(varname string, path string, _ parse.Node) {},
 			func(varname string, node, path string) {},
 		)
 	case *parse.IfNode:
 		walk(&tn.BranchNode, parentNode, parentPath, foundPathFn, foundTemplateFn, foundVarUsageFn, foundVarDefFn)
 	case *parse.RangeNode:
 		walk(tn.Pipe, parentNode, parentPath,
-			func(path string) {
-				foundPathFn(path + "[*]")
+			func(path string, node parse.Node) {
+				foundPathFn(path+"[*]", node)
 			},
-			func(templateName, context string) {
-				foundTemplateFn(templateName, context+"[*]")
+			func(templateName, context string, node parse.Node) {
+				foundTemplateFn(templateName, context+"[*]", node)
 			},
-			func(varname string, path string) {
-				foundVarUsageFn(varname, path+"[*]")
+			func(varname string, path string, node parse.Node) {
+				foundVarUsageFn(varname, path+"[*]", node)
 			},
 			func(varname string, node, path string) {
 				foundVarDefFn(varname, node, path+"[*]")
@@ -333,14 +589,14 @@ func walk(
 		})
 	case *parse.WithNode:
 		walk(tn.Pipe, parentNode, parentPath,
-			func(path string) {
-				foundPathFn(path)
+			func(path string, node parse.Node) {
+				foundPathFn(path, node)
 			},
-			func(templateName, context string) {
-				foundTemplateFn(templateName, context)
+			func(templateName, context string, node parse.Node) {
+				foundTemplateFn(templateName, context, node)
 			},
-			func(varname string, path string) {
-				foundVarUsageFn(varname, path)
+			func(varname string, path string, node parse.Node) {
+				foundVarUsageFn(varname, path, node)
 			},
 			func(varname string, node, path string) {
 				foundVarDefFn(varname, node, path)
@@ -353,15 +609,89 @@ func walk(
 	}
 }
 
+// valueAccess is a call of a function that reads the value of a key of its
+// map or list arguments, eg. 'index .Values "foo-bar"' or
+// 'dig "a" "b" "" .Values'.
+type valueAccess struct {
+	// dicts are the arguments the keys are read from
+	dicts []parse.Node
+	// suffix is the path of the keys, relative to the dicts
+	suffix string
+	// others are the arguments that are not keys, eg. the default of dig,
+	// and the keys that are not literals
+	others []parse.Node
+}
+
+func parseValueAccess(cmd *parse.CommandNode) (valueAccess, bool) {
+	if len(cmd.Args) < 2 || cmd.Args[0].Type() != parse.NodeIdentifier {
+		return valueAccess{}, false
+	}
+
+	args := cmd.Args[1:]
+	switch cmd.Args[0].(*parse.IdentifierNode).Ident {
+	case "index":
+		return newValueAccess(args[:1], args[1:], nil), true
+	case "get", "hasKey":
+		if len(args) != 2 {
+			return valueAccess{}, false
+		}
+		return newValueAccess(args[:1], args[1:], nil), true
+	case "dig":
+		if len(args) < 3 {
+			return valueAccess{}, false
+		}
+		return newValueAccess(args[len(args)-1:], args[:len(args)-2], args[len(args)-2:len(args)-1]), true
+	case "pluck":
+		return newValueAccess(args[1:], args[:1], nil), true
+	}
+
+	return valueAccess{}, false
+}
+
+func newValueAccess(dicts []parse.Node, keys []parse.Node, others []parse.Node) valueAccess {
+	access := valueAccess{dicts: dicts, others: others}
+	for i, key := range keys {
+		segment, ok := keySegment(key)
+		if !ok {
+			// a dynamic key can read any value below the previous keys, so
+			// the path stops there and the whole subtree is used
+			access.suffix += DynamicKey
+			access.others = append(access.others, keys[i:]...)
+			break
+		}
+		access.suffix += segment
+	}
+
+	return access
+}
+
+// keySegment returns the path segment of a literal key, formatted like
+// paths.Path.PatternString. It returns false for dynamic keys.
+func keySegment(key parse.Node) (string, bool) {
+	switch key := key.(type) {
+	case *parse.StringNode:
+		if strings.Contains(key.Text, ".") {
+			return fmt.Sprintf("[%q]", key.Text), true
+		}
+		return "." + key.Text, true
+	case *parse.NumberNode:
+		if key.IsInt {
+			return "[*]", true
+		}
+	}
+
+	return "", false
+}
+
 func foreachPath(node parse.Node, parentNode string, parentPath string, found func(string)) {
 	walk(node, parentNode, parentPath,
-		func(path string) {
+		func(path string, _ parse.Node) {
 			found(path)
 		},
-		func(_, context string) {
+		func(_, context string, _ parse.Node) {
 			found(context)
 		},
-		func(varname string, path string) {
+		func(varname string, path string, _ parse.Node) {
 			found(path)
 		},
 		func(varname string, node, path string) {
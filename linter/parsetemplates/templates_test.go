@@ -18,6 +18,8 @@ package parsetemplates_test
 
 import (
 	"fmt"
+	"os"
+	"path/filepath"
 	"sort"
 	"strings"
 	"testing"
@@ -257,6 +259,79 @@ func TestListTemplatePathsFromTemplates(t *testing.T) {
 				"test2",
 			},
 		},
+		{
+			templates: []string{
+				"{{ index .Values \"foo-bar\" \"baz\" }}{{ index .Values.list 0 \"name\" }}",
+			},
+			expectedPaths: []string{
+				"foo-bar.baz",
+				"list[*].name",
+			},
+		},
+		{
+			templates: []string{
+				"{{ get .Values.podLabels \"app.kubernetes.io/name\" }}{{ if hasKey .Values \"webhook\" }}{{ end }}",
+			},
+			expectedPaths: []string{
+				`podLabels["app.kubernetes.io/name"]`,
+				"webhook",
+			},
+		},
+		{
+			templates: []string{
+				"{{ dig \"a\" \"b\" .Values.fallback .Values }}{{ pluck \"c\" .Values.test1 .Values.test2 | first }}",
+			},
+			expectedPaths: []string{
+				"a.b",
+				"fallback",
+				"test1.c",
+				"test2.c",
+			},
+		},
+		{
+			templates: []string{
+				"{{ $value := index .Values.test1 \"test2\" }}{{ $value.test3 }}{{ with get . \"test4\" }}{{ .test5 }}{{ end }}",
+			},
+			expectedPaths: []string{
+				"test1.test2.test3",
+			},
+		},
+		{
+			templates: []string{
+				"{{ define \"T1\" }}{{ index . \"test2\" \"test3\" }}{{ end }}",
+				"{{ template \"T1\" (index .Values \"test1\") }}",
+			},
+			expectedPaths: []string{
+				"test1.test2.test3",
+			},
+		},
+		{
+			templates: []string{
+				"{{ index .Values.test1 .Values.key \"test2\" }}",
+			},
+			expectedPaths: []string{
+				"test1",
+				"key",
+			},
+		},
+		{
+			templates: []string{
+				"{{ index .Values \"a.b\" \"c\" }}{{ get .Values \"d.e\" }}",
+			},
+			expectedPaths: []string{
+				`["a.b"].c`,
+				`["d.e"]`,
+			},
+		},
+		{
+			// a dynamic key of .Values can read any value
+			templates: []string{
+				"{{ $key := \"test1\" }}{{ index .Values $key }}",
+			},
+			expectedPaths: []string{
+				"",
+			},
+		},
 	}
 
 	for _, tc := range testcases {
@@ -315,3 +390,87 @@ func TestListTemplatePathsExponentialLattice(t *testing.T) {
 	require.Error(t, err, "expected the exponential lattice to be rejected, not enumerated")
 	require.Contains(t, err.Error(), "too complex")
 }
+
+func TestListTemplateUsages(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "sub"), 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "_helpers.tpl"), []byte(`
+{{- define "image" -}}
+{{ .repository }}:{{ .tag }}
+{{- end -}}
+`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "sub", "deployment.yaml"), []byte(`
+image: {{ include "image" .Values.image }}
+{{- $webhook := .Values.webhook }}
+port: {{ $webhook.port }}
+tag: {{ .Values.image.tag }}
+resources: {{ toYaml .Values.resources }}
+limits: {{ .Values.resources.limits.cpu }}
+`), 0600))
+
+	usages, err := parsetemplates.ListTemplateUsages(dir)
+	require.NoError(t, err)
+
+	// The usages of the objects containing a path, eg. the context of the
+	// include or toYaml .Values.resources, are usages of the path as well
+	include := parsetemplates.Position{Template: "sub/deployment.yaml", Line: 2, Column: 10}
+	image := parsetemplates.Position{Template: "sub/deployment.yaml", Line: 2, Column: 33}
+	require.Equal(t, parsetemplates.Usages{
+		"image.repository": {
+			{Position: parsetemplates.Position{Template: "_helpers.tpl", Line: 3, Column: 3}, Chain: []parsetemplates.Position{include}},
+			{Position: image},
+		},
+		"image.tag": {
+			{Position: parsetemplates.Position{Template: "_helpers.tpl", Line: 3, Column: 21}, Chain: []parsetemplates.Position{include}},
+			{Position: image},
+			{Position: parsetemplates.Position{Template: "sub/deployment.yaml", Line: 5, Column: 15}},
+		},
+		"webhook.port": {
+			{Position: parsetemplates.Position{Template: "sub/deployment.yaml", Line: 3, Column: 23}},
+			{Position: parsetemplates.Position{Template: "sub/deployment.yaml", Line: 4, Column: 17}},
+		},
+		"resources.limits.cpu": {
+			{Position: parsetemplates.Position{Template: "sub/deployment.yaml", Line: 6, Column: 28}},
+			{Position: parsetemplates.Position{Template: "sub/deployment.yaml", Line: 7, Column: 18}},
+		},
+	}, usages)
+}
+
+func TestListTemplateUsages_IncludeChain(t *testing.T) {
+	tmpl := template.New("ROOT")
+	tmpl.Funcs(funcs_serdes.FuncMap())
+
+	_, err := tmpl.New("_helpers.tpl").Parse(`{{- define "labels" }}
+{{- include "name" .Values.nameOverride }}
+{{- end }}
+{{- define "name" }}{{ .short }}{{ end }}`)
+	require.NoError(t, err)
+
+	templates := sets.Set[*template.Template]{}
+	for _, name := range []string{"a.yaml", "b.yaml"} {
+		tpl, err := tmpl.New(name).Parse(`
+{{ template "labels" . }}`)
+		require.NoError(t, err)
+		templates[tpl] = struct{}{}
+	}
+
+	usages, err := parsetemplates.ListTemplateUsagesFromTemplates(tmpl, templates)
+	require.NoError(t, err)
+
+	// Both files call labels with the same context, the first call is kept
+	require.Equal(t, parsetemplates.Usages{
+		"nameOverride.short": {
+			{
+				Position: parsetemplates.Position{Template: "_helpers.tpl", Line: 2, Column: 26},
+				Chain:    []parsetemplates.Position{{Template: "a.yaml", Line: 2, Column: 12}},
+			},
+			{
+				Position: parsetemplates.Position{Template: "_helpers.tpl", Line: 4, Column: 23},
+				Chain: []parsetemplates.Position{
+					{Template: "a.yaml", Line: 2, Column: 12},
+					{Template: "_helpers.tpl", Line: 2, Column: 4},
+				},
+			},
+		},
+	}, usages)
+}
@@ -0,0 +1,210 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package linter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+type Format string
+
+const (
+	FormatText   Format = "text"
+	FormatJSON   Format = "json"
+	FormatSARIF  Format = "sarif"
+	FormatGitHub Format = "github"
+)
+
+// Report formats the findings: one line per finding as text or as GitHub
+// Actions workflow commands (which annotate pull requests), a JSON list or a
+// SARIF log.
+func Report(findings []Finding, format Format) (string, error) {
+	switch format {
+	case FormatText:
+		var lines []string
+		for _, finding := range findings {
+			if location, ok := finding.Location(); ok {
+				lines = append(lines, fmt.Sprintf("%s: %s", location, finding.Message))
+			} else {
+				lines = append(lines, finding.Message)
+			}
+		}
+		return strings.Join(lines, "\n"), nil
+
+	case FormatJSON:
+		if findings == nil {
+			findings = []Finding{}
+		}
+		data, err := json.MarshalIndent(findings, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+
+	case FormatSARIF:
+		data, err := json.MarshalIndent(sarifLog(findings), "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+
+	case FormatGitHub:
+		var lines []string
+		for _, finding := range findings {
+			properties := []string{}
+			if location, ok := finding.Location(); ok {
+				properties = append(properties, "file="+escapeGitHubProperty(filepath.ToSlash(location.File)))
+				if location.Line > 0 {
+					properties = append(properties, fmt.Sprintf("line=%d", location.Line))
+				}
+			}
+			properties = append(properties, "title="+escapeGitHubProperty("helm-tool "+finding.Rule))
+
+			lines = append(lines, fmt.Sprintf("::%s %s::%s", finding.Severity, strings.Join(properties, ","), escapeGitHubData(finding.Message)))
+		}
+		return strings.Join(lines, "\n"), nil
+
+	default:
+		return "", fmt.Errorf("unknown format %q, expected %q, %q, %q or %q", format, FormatText, FormatJSON, FormatSARIF, FormatGitHub)
+	}
+}
+
+var (
+	gitHubDataEscaper     = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A")
+	gitHubPropertyEscaper = strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C")
+)
+
+func escapeGitHubData(data string) string {
+	return gitHubDataEscaper.Replace(data)
+}
+
+func escapeGitHubProperty(property string) string {
+	return gitHubPropertyEscaper.Replace(property)
+}
+
+// The subset of the SARIF 2.1.0 format written by the linter, see
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifRule struct {
+	ID                   string       `json:"id"`
+	ShortDescription     sarifMessage `json:"shortDescription"`
+	DefaultConfiguration struct {
+		Level Severity `json:"level"`
+	} `json:"defaultConfiguration"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     Severity        `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifRun struct {
+	Tool struct {
+		Driver struct {
+			Name           string      `json:"name"`
+			InformationURI string      `json:"informationUri"`
+			Rules          []sarifRule `json:"rules"`
+		} `json:"driver"`
+	} `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarif struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+func sarifLog(findings []Finding) sarif {
+	var run sarifRun
+	run.Tool.Driver.Name = "helm-tool"
+	run.Tool.Driver.InformationURI = "https://github.com/cert-manager/helm-tool"
+	for _, r := range rules {
+		sarifRule := sarifRule{ID: r.id, ShortDescription: sarifMessage{Text: r.description}}
+		sarifRule.DefaultConfiguration.Level = r.severity
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule)
+	}
+
+	run.Results = []sarifResult{}
+	for _, finding := range findings {
+		result := sarifResult{
+			RuleID:  finding.Rule,
+			Level:   finding.Severity,
+			Message: sarifMessage{Text: finding.Message},
+		}
+
+		locations := finding.Templates
+		if location, ok := finding.Location(); ok && len(locations) == 0 {
+			locations = []Location{location}
+		}
+		for _, location := range locations {
+			physicalLocation := sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: sarifURI(location.File)}}
+			if location.Line > 0 {
+				physicalLocation.Region = &sarifRegion{StartLine: location.Line}
+			}
+			result.Locations = append(result.Locations, sarifLocation{PhysicalLocation: physicalLocation})
+		}
+
+		run.Results = append(run.Results, result)
+	}
+
+	return sarif{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}
+}
+
+// sarifURI returns the URI of a file, relative paths are kept relative so the
+// results can be matched with the files of the repository.
+func sarifURI(file string) string {
+	uri := url.URL{Path: filepath.ToSlash(file)}
+	if filepath.IsAbs(file) {
+		uri.Scheme = "file"
+		if !strings.HasPrefix(uri.Path, "/") {
+			uri.Path = "/" + uri.Path
+		}
+	}
+
+	return uri.String()
+}
@@ -22,6 +22,17 @@ import (
 	"strings"
 )
 
+// HasPathPrefix reports whether the path extends the prefix, ie. starts with
+// the prefix followed by a period or an opening square bracket. The empty
+// path, the whole values, is a prefix of every other path.
+func HasPathPrefix(path string, prefix string) bool {
+	if prefix == "" {
+		return path != ""
+	}
+
+	return strings.HasPrefix(path, prefix+".") || strings.HasPrefix(path, prefix+"[")
+}
+
 // RemovePrefixes returns a new set with all items from
 // the input set that are not a prefix of another item in
 // the set or any of the additional sets. We consider a
@@ -41,7 +52,7 @@ func RemovePrefixes(items Set[string], sets ...Set[string]) Set[string] {
 	for i := range values {
 		// If the next value is an extension of the current value, remove
 		// the current value.
-		if i+1 < len(values) && (strings.HasPrefix(values[i+1], values[i]+".") || strings.HasPrefix(values[i+1], values[i]+"[")) {
+		if i+1 < len(values) && HasPathPrefix(values[i+1], values[i]) {
 			nonPrefixes.Delete(values[i])
 		}
 	}
@@ -69,7 +80,7 @@ OuterLoop:
 	for i := range values {
 		// Remove all following values that are extensions of the current value.
 		for j := i + 1; j < len(values); j++ {
-			if !strings.HasPrefix(values[j], values[i]+".") && !strings.HasPrefix(values[j], values[i]+"[") {
+			if !HasPathPrefix(values[j], values[i]) {
 				continue OuterLoop
 			}
 
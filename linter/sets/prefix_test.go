@@ -39,6 +39,11 @@ func TestRemovePrefixes(t *testing.T) {
 			additional: New("a.b.c"),
 			expected:   New("a.d"),
 		},
+		{
+			input:      New("", "a", `["b.c"]`),
+			additional: New[string](),
+			expected:   New("a", `["b.c"]`),
+		},
 	}
 
 	for i, tt := range tests {
@@ -65,6 +70,11 @@ func TestRemoveExtensions(t *testing.T) {
 			additional: New("a.b"),
 			expected:   New("a.b", "a.d"),
 		},
+		{
+			input:      New("", "a.b", `["c.d"]`),
+			additional: New[string](),
+			expected:   New(""),
+		},
 	}
 
 	for i, tt := range tests {
@@ -19,24 +19,45 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"regexp"
+	"text/tabwriter"
 
 	"github.com/spf13/cobra"
 
+	"github.com/cert-manager/helm-tool/chart"
+	"github.com/cert-manager/helm-tool/dump"
 	"github.com/cert-manager/helm-tool/linter"
 	"github.com/cert-manager/helm-tool/parser"
+	"github.com/cert-manager/helm-tool/paths"
 	"github.com/cert-manager/helm-tool/render"
 	"github.com/cert-manager/helm-tool/schema"
 )
 
 var (
-	valuesFile      string
-	templatesFolder string
-	exceptionsFile  string
-	targetFile      string
-	templateName    string
-	headerSearch    = regexValue{regexp.MustCompile(`(?m)^##\s+Parameters *$`)}
-	footerSearch    = regexValue{regexp.MustCompile(`(?m)^##?\s+.*$`)}
+	valuesFile       string
+	templatesFolder  string
+	exceptionsFile   string
+	writeExceptions  bool
+	targetFile       string
+	templateName     string
+	withSubcharts    bool
+	externalRefs     bool
+	validateValues   bool
+	exitCodeOnChange bool
+	dumpFormat       string
+	splitDir         string
+	listPartials     bool
+	withChart        bool
+	lintFormat       string
+	renderOptions    render.Options
+	splitOptions     render.SplitOptions
+	dumpHidden       bool
+	setValues        []string
+	setStringValues  []string
+	setJSONValues    []string
+	headerSearch     = regexValue{regexp.MustCompile(`(?m)^##\s+Parameters *$`)}
+	footerSearch     = regexValue{regexp.MustCompile(`(?m)^##?\s+.*$`)}
 )
 
 var Cmd = cobra.Command{
@@ -47,13 +68,45 @@ var Render = cobra.Command{
 	Use:   "render",
 	Short: "render documentation to stdout",
 	Run: func(cmd *cobra.Command, args []string) {
+		if listPartials {
+			partials, err := render.ListPartials(templateName)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Could not list partials of %q: %s\n", templateName, err)
+				os.Exit(1)
+			}
+
+			writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			for _, partial := range partials {
+				fmt.Fprintf(writer, "%s\t%s\n", partial.Name, partial.Description)
+			}
+			writer.Flush()
+			return
+		}
+
 		document, err := parser.Load(valuesFile, false)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Could not open %q: %s\n", valuesFile, err)
 			os.Exit(1)
 		}
 
-		result, err := render.Render(templateName, document)
+		addSubcharts(document)
+		loadChart()
+
+		if splitDir != "" {
+			splitOptions.Options = renderOptions
+			written, err := render.RenderSplit(templateName, document, splitDir, splitOptions)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error rendering template: %s\n", err)
+				os.Exit(1)
+			}
+
+			for _, path := range written {
+				fmt.Println(path)
+			}
+			return
+		}
+
+		result, err := render.RenderWithOptions(templateName, document, renderOptions)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error rendering template: %s\n", err)
 			os.Exit(1)
@@ -66,6 +119,18 @@ var Render = cobra.Command{
 var Inject = cobra.Command{
 	Use:   "inject",
 	Short: "generate documentation and inject into existing markdown file",
+	Long: `Generate documentation and inject it into an existing markdown file.
+
+If the file contains marker comments, the documentation is injected into every
+marked region, each with its own template and sections:
+
+  <!-- helm-tool:begin name=properties template=markdown-table sections="Global" -->
+  <!-- helm-tool:end -->
+
+In AsciiDoc and reStructuredText files, the markers are written as comments of
+that format ("// helm-tool:begin ..." and ".. helm-tool:begin ...").
+
+Otherwise it is injected between the header and footer matches.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		document, err := parser.Load(valuesFile, false)
 		if err != nil {
@@ -73,10 +138,24 @@ var Inject = cobra.Command{
 			os.Exit(1)
 		}
 
-		if err := render.Inject(targetFile, templateName, document, headerSearch.regexp, footerSearch.regexp); err != nil {
-			fmt.Fprintf(os.Stderr, "Could inject markdown into %q: %s\n", targetFile, err)
+		addSubcharts(document)
+		loadChart()
+
+		changed, err := render.InjectWithOptions(targetFile, templateName, document, headerSearch.regexp, footerSearch.regexp, renderOptions)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Could not inject markdown into %q: %s\n", targetFile, err)
 			os.Exit(1)
 		}
+
+		if !changed {
+			fmt.Fprintf(os.Stderr, "%q is up to date\n", targetFile)
+			return
+		}
+
+		fmt.Fprintf(os.Stderr, "Updated %q\n", targetFile)
+		if exitCodeOnChange {
+			os.Exit(2)
+		}
 	},
 }
 
@@ -89,7 +168,12 @@ var Schema = cobra.Command{
 			os.Exit(1)
 		}
 
-		renderedSchema, err := schema.Render(document)
+		options := schema.Options{ExternalRefs: externalRefs, ValidateValues: validateValues}
+		if withSubcharts {
+			options.ChartDir = filepath.Dir(valuesFile)
+		}
+
+		renderedSchema, err := schema.RenderWithOptions(document, options)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Could not render schema: %s\n", err)
 			os.Exit(1)
@@ -99,8 +183,29 @@ var Schema = cobra.Command{
 	},
 }
 
-var Lint = cobra.Command{
-	Use: "lint",
+var Example = cobra.Command{
+	Use:   "example",
+	Short: "generate an example values file documenting every property, with the undefaulted properties commented out",
+	Run: func(cmd *cobra.Command, args []string) {
+		document, err := parser.Load(valuesFile, false)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Could not open %q: %s\n", valuesFile, err)
+			os.Exit(1)
+		}
+
+		result, err := render.ExampleValues(document)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Could not generate example values: %s\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Print(result)
+	},
+}
+
+var CheckSet = cobra.Command{
+	Use:   "check-set",
+	Short: "check that Helm --set, --set-string and --set-json flags set documented properties",
 	Run: func(cmd *cobra.Command, args []string) {
 		document, err := parser.Load(valuesFile, true)
 		if err != nil {
@@ -108,16 +213,163 @@ var Lint = cobra.Command{
 			os.Exit(1)
 		}
 
-		err = linter.Lint(templatesFolder, exceptionsFile, document)
+		var setPaths []paths.Path
+		for _, expression := range append(append([]string{}, setValues...), setStringValues...) {
+			result, err := paths.ParseSet(expression)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Could not parse %q: %s\n", expression, err)
+				os.Exit(1)
+			}
+			setPaths = append(setPaths, result...)
+		}
+		for _, expression := range setJSONValues {
+			result, err := paths.ParseSetJSON(expression)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Could not parse %q: %s\n", expression, err)
+				os.Exit(1)
+			}
+			setPaths = append(setPaths, result...)
+		}
+
+		undocumented := false
+		for _, path := range setPaths {
+			property, ok := document.PropertyOf(path)
+			if !ok {
+				fmt.Fprintf(os.Stderr, "%s: not a documented property\n", path.SetKey())
+				undocumented = true
+				continue
+			}
+
+			fmt.Printf("%s: sets %s (%s)\n", path.SetKey(), property.Path, property.DisplayType())
+		}
+
+		if undocumented {
+			os.Exit(1)
+		}
+	},
+}
+
+var Dump = cobra.Command{
+	Use:   "dump",
+	Short: "dump the parsed documentation as JSON or YAML, for use by other tools",
+	Run: func(cmd *cobra.Command, args []string) {
+		document, err := parser.Load(valuesFile, dumpHidden)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Could not open %q: %s\n", valuesFile, err)
+			os.Exit(1)
+		}
+
+		result, err := dump.Render(document, dump.Format(dumpFormat))
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Could not lint: %s\n", err)
+			fmt.Fprintf(os.Stderr, "Could not dump documentation: %s\n", err)
 			os.Exit(1)
 		}
 
-		fmt.Println("No errors found")
+		fmt.Println(result)
 	},
 }
 
+var Lint = cobra.Command{
+	Use: "lint",
+	Run: func(cmd *cobra.Command, args []string) {
+		if code := lint(); code != 0 {
+			os.Exit(code)
+		}
+	},
+}
+
+// lint runs the lint command and returns its exit status, 1 if a finding
+// fails the lint or the values could not be linted.
+func lint() int {
+	document, err := parser.Load(valuesFile, true)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not open %q: %s\n", valuesFile, err)
+		return 1
+	}
+
+	if writeExceptions {
+		if exceptionsFile == "" {
+			fmt.Fprintf(os.Stderr, "--write-exceptions requires an exceptions file (-e)\n")
+			return 1
+		}
+
+		findings, err := linter.Lint(templatesFolder, "", valuesFile, document)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Could not lint: %s\n", err)
+			return 1
+		}
+
+		added, removed, err := linter.WriteExceptions(exceptionsFile, findings)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Could not write %q: %s\n", exceptionsFile, err)
+			return 1
+		}
+
+		fmt.Printf("Updated %q: %d exceptions added, %d stale exceptions removed\n", exceptionsFile, added, removed)
+		return 0
+	}
+
+	findings, err := linter.Lint(templatesFolder, exceptionsFile, valuesFile, document)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not lint: %s\n", err)
+		return 1
+	}
+
+	report, err := linter.Report(findings, linter.Format(lintFormat))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not report findings: %s\n", err)
+		return 1
+	}
+
+	if report != "" {
+		fmt.Println(report)
+	}
+
+	if errorCount := linter.CountErrors(findings); errorCount > 0 {
+		fmt.Fprintf(os.Stderr, "Could not lint: values.yaml and templates are not in sync, %d errors and %d warnings\n", errorCount, len(findings)-errorCount)
+		return 1
+	}
+
+	if linter.Format(lintFormat) == linter.FormatText {
+		if len(findings) > 0 {
+			fmt.Printf("No errors found, %d warnings\n", len(findings))
+		} else {
+			fmt.Println("No errors found")
+		}
+	}
+
+	return 0
+}
+
+// addSubcharts appends the documentation of the Chart.yaml dependencies to the
+// document, if requested with --subcharts.
+func addSubcharts(document *parser.Document) {
+	if !withSubcharts {
+		return
+	}
+
+	if err := parser.AddSubcharts(document, filepath.Dir(valuesFile), false); err != nil {
+		fmt.Fprintf(os.Stderr, "Could not load subcharts: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+// loadChart reads the Chart.yaml next to the values file into the render
+// options, if requested with --chart.
+func loadChart() {
+	if !withChart {
+		return
+	}
+
+	metadata, err := chart.Load(filepath.Dir(valuesFile))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Could not read Chart.yaml: %s\n", err)
+		os.Exit(1)
+	}
+
+	renderOptions.Chart = metadata
+}
+
 func init() {
 	Cmd.PersistentFlags().StringVarP(&valuesFile, "values", "i", "values.yaml", "values file used to generate the documentation")
 
@@ -126,15 +378,55 @@ func init() {
 	Inject.PersistentFlags().StringVarP(&targetFile, "output", "o", "README.md", "file to inject the generated markdown into")
 	Inject.PersistentFlags().Var(&headerSearch, "header-search", "set the regex used to match the start of the injected markdown")
 	Inject.PersistentFlags().Var(&footerSearch, "footer-search", "set the regex used to match the end of the injected markdown")
+	Inject.PersistentFlags().BoolVar(&exitCodeOnChange, "exit-code", false, "exit with status 2 if the file was changed, eg. to check that the documentation is up to date in CI")
+	Inject.PersistentFlags().BoolVar(&renderOptions.TOC, "toc", false, "add a table of contents linking every section and property")
+	Inject.PersistentFlags().IntVar(&renderOptions.CollapseDefaults, "collapse-defaults", 0, "collapse default values with more lines than this, 0 to show all defaults in full")
+	Inject.PersistentFlags().StringVar((*string)(&renderOptions.Sort), "sort", "file", "order of the properties within a section: file, path or type")
+	Inject.PersistentFlags().StringArrayVar(&renderOptions.Include, "include", nil, "only render the properties matching this path glob, eg. 'webhook.*' (can be repeated)")
+	Inject.PersistentFlags().StringArrayVar(&renderOptions.Exclude, "exclude", nil, "do not render the properties matching this path glob (can be repeated)")
+	Inject.PersistentFlags().StringArrayVar(&renderOptions.Sections, "section", nil, "only render this section (can be repeated)")
+	Inject.PersistentFlags().StringVar(&renderOptions.Partials, "partials", "", "directory of .tpl files whose define blocks override the partials of the template")
+	Inject.PersistentFlags().BoolVar(&withSubcharts, "subcharts", false, "document the values of the Chart.yaml dependencies found in the charts/ directory, nested under their name or alias")
+	Inject.PersistentFlags().BoolVar(&withChart, "chart", false, "read the Chart.yaml next to the values file, templates get it as .Chart (eg. the chart-header template)")
 
 	Cmd.AddCommand(&Render)
 	Render.PersistentFlags().StringVarP(&templateName, "template", "t", "markdown-plain", "built-in template name or path to a custom template")
+	Render.PersistentFlags().BoolVar(&renderOptions.TOC, "toc", false, "add a table of contents linking every section and property")
+	Render.PersistentFlags().IntVar(&renderOptions.CollapseDefaults, "collapse-defaults", 0, "collapse default values with more lines than this, 0 to show all defaults in full")
+	Render.PersistentFlags().StringVar((*string)(&renderOptions.Sort), "sort", "file", "order of the properties within a section: file, path or type")
+	Render.PersistentFlags().StringArrayVar(&renderOptions.Include, "include", nil, "only render the properties matching this path glob, eg. 'webhook.*' (can be repeated)")
+	Render.PersistentFlags().StringArrayVar(&renderOptions.Exclude, "exclude", nil, "do not render the properties matching this path glob (can be repeated)")
+	Render.PersistentFlags().StringArrayVar(&renderOptions.Sections, "section", nil, "only render this section (can be repeated)")
+	Render.PersistentFlags().StringVar(&renderOptions.Partials, "partials", "", "directory of .tpl files whose define blocks override the partials of the template")
+	Render.PersistentFlags().BoolVar(&withSubcharts, "subcharts", false, "document the values of the Chart.yaml dependencies found in the charts/ directory, nested under their name or alias")
+	Render.PersistentFlags().BoolVar(&withChart, "chart", false, "read the Chart.yaml next to the values file, templates get it as .Chart (eg. the chart-header template)")
+	Render.PersistentFlags().BoolVar(&listPartials, "list-partials", false, "list the partials of the template that can be overridden with --partials, instead of rendering")
+	Render.PersistentFlags().StringVar(&splitDir, "split-dir", "", "write every section to its own file in this directory, with front matter, instead of rendering to stdout")
+	Render.PersistentFlags().StringVar(&splitOptions.FilenamePattern, "split-filename", "{{ .Slug }}.md", "template for the file names of the sections, with the fields .Title, .Slug and .Weight")
+	Render.PersistentFlags().StringVar(&splitOptions.IndexFile, "split-index", "_index.md", "file name of the index page linking all sections, empty to skip the index")
+	Render.PersistentFlags().StringVar(&splitOptions.UntitledSection, "split-untitled", "General", "title of the properties before the first +docs:section tag")
 
 	Cmd.AddCommand(&Schema)
+	Schema.PersistentFlags().BoolVar(&withSubcharts, "subcharts", false, "nest the schemas of the Chart.yaml dependencies found in the charts/ directory under their name or alias")
+	Schema.PersistentFlags().BoolVar(&externalRefs, "external-refs", false, "reference shared schema files of +docs:ref tags by their relative path instead of bundling them")
+	Schema.PersistentFlags().BoolVar(&validateValues, "validate-values", false, "validate the values file as a whole against the generated schema, including the +docs:requiredWhen and +docs:exclusiveWith relations")
+
+	Cmd.AddCommand(&Example)
+
+	Cmd.AddCommand(&CheckSet)
+	CheckSet.PersistentFlags().StringArrayVar(&setValues, "set", nil, "a --set flag of helm install, eg. image.tag=v1")
+	CheckSet.PersistentFlags().StringArrayVar(&setStringValues, "set-string", nil, "a --set-string flag of helm install")
+	CheckSet.PersistentFlags().StringArrayVar(&setJSONValues, "set-json", nil, "a --set-json flag of helm install")
+
+	Cmd.AddCommand(&Dump)
+	Dump.PersistentFlags().StringVarP(&dumpFormat, "format", "f", "json", "output format, json or yaml")
+	Dump.PersistentFlags().BoolVar(&dumpHidden, "include-hidden", false, "include the properties hidden with +docs:hidden")
 
 	Cmd.AddCommand(&Lint)
 	Lint.PersistentFlags().StringVarP(&templatesFolder, "templates", "d", "templates", "templates folder used to lint the values file")
 	Lint.PersistentFlags().StringVarP(&exceptionsFile, "exceptions", "e", "", "file containing exceptions to the linting rules")
+	Lint.PersistentFlags().BoolVar(&writeExceptions, "write-exceptions", false, "update the exceptions file to accept the current findings, instead of reporting them")
+	Lint.PersistentFlags().StringVarP(&lintFormat, "format", "f", "text", "output format of the findings: text, json, sarif or github (annotations for GitHub Actions)")
 }
 
 func main() {
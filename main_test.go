@@ -0,0 +1,77 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLint_ExitStatus(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   string
+		template string
+		status   int
+	}{
+		{
+			name:     "in sync",
+			values:   "a: 1\n",
+			template: "{{ .Values.a }}\n",
+			status:   0,
+		},
+		{
+			name:     "undefined value",
+			values:   "a: 1\n",
+			template: "{{ .Values.a }}{{ .Values.b }}\n",
+			status:   1,
+		},
+		{
+			name:     "unused value",
+			values:   "a: 1\nb: 2\n",
+			template: "{{ .Values.a }}\n",
+			status:   1,
+		},
+		{
+			name:     "see target only",
+			values:   "# +docs:see=missing\na: 1\n",
+			template: "{{ .Values.a }}\n",
+			status:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.MkdirAll(filepath.Join(dir, "templates"), 0755))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "values.yaml"), []byte(tt.values), 0600))
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "templates", "a.yaml"), []byte(tt.template), 0600))
+
+			valuesFile = filepath.Join(dir, "values.yaml")
+			templatesFolder = filepath.Join(dir, "templates")
+			exceptionsFile = ""
+			writeExceptions = false
+			lintFormat = "text"
+
+			assert.Equal(t, tt.status, lint())
+		})
+	}
+}
@@ -16,7 +16,11 @@ limitations under the License.
 
 package parser
 
-import "github.com/cert-manager/helm-tool/heuristics"
+import (
+	"strings"
+
+	"github.com/cert-manager/helm-tool/heuristics"
+)
 
 type Comment struct {
 	heuristics.CommentBlock
@@ -38,3 +42,13 @@ func parseComments(comment string) (comments []Comment) {
 
 	return
 }
+
+// textComment returns a comment made of a single text segment.
+func textComment(text string) Comment {
+	return Comment{CommentBlock: heuristics.CommentBlock{
+		Segments: []heuristics.CommentBlockSegment{{
+			Type:     heuristics.ContentTypeText,
+			Contents: strings.Split(text, "\n"),
+		}},
+	}}
+}
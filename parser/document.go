@@ -17,14 +17,18 @@ limitations under the License.
 package parser
 
 import (
+	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"go.yaml.in/yaml/v3"
 
 	"github.com/cert-manager/helm-tool/heuristics"
 	"github.com/cert-manager/helm-tool/paths"
+	"github.com/cert-manager/helm-tool/refs"
 )
 
 const (
@@ -34,10 +38,63 @@ const (
 	TagType     = "docs:type"
 	TagDefault  = "docs:default"
 	TagProperty = "docs:property"
+
+	TagRequiredWhen  = "docs:requiredWhen"
+	TagExclusiveWith = "docs:exclusiveWith"
+	TagDeprecated    = "docs:deprecated"
+	TagRef           = "docs:ref"
+	TagSee           = "docs:see"
+
+	TagDefaultDisplay = "docs:defaultDisplay"
+
+	// TagExtensionPrefix is the prefix of tags that are copied into the
+	// JSON schema as vendor extensions, eg. +docs:x-foo=bar.
+	TagExtensionPrefix = "docs:x-"
 )
 
 type Document struct {
 	Sections []Section
+
+	// root is the YAML node of the values file, nil if the document was not
+	// loaded from a file.
+	root *yaml.Node
+}
+
+// Values decodes the values of the values file, nil if the document was not
+// loaded from a file. Helm validates them, merged with the values set on
+// install, against the JSON schema. Decoding fails on values that cannot be
+// expanded, eg. an anchor that contains itself or excessive aliasing.
+func (d *Document) Values() (any, error) {
+	if d.root == nil {
+		return nil, nil
+	}
+
+	var values any
+	if err := d.root.Decode(&values); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// PropertyOf returns the documented property that setting the path changes,
+// the property at the path or the object or array property containing it.
+func (d *Document) PropertyOf(path paths.Path) (Property, bool) {
+	var result Property
+	found := false
+	for _, section := range d.Sections {
+		for _, property := range section.Properties {
+			if !property.Path.IsSubPathOf(path) || (found && len(property.Path) <= len(result.Path)) {
+				continue
+			}
+
+			if property.Path.Equal(path) || property.Type == TypeObject || property.Type == TypeArray {
+				result, found = property, true
+			}
+		}
+	}
+
+	return result, found
 }
 
 type Section struct {
@@ -50,7 +107,78 @@ type Property struct {
 	Path        paths.Path
 	Description Comment
 	Type        Type
+	Format      Format
 	Default     string
+
+	// RequiredWhen lists the properties that, when set, make this property
+	// required.
+	RequiredWhen []paths.Path
+	// ExclusiveWith lists the properties that cannot be set together with
+	// this property.
+	ExclusiveWith []paths.Path
+	// See lists the related properties the documentation links to, set by
+	// the +docs:see tag.
+	See []paths.Path
+	// Ref points to the shared definition of this property, set by the
+	// +docs:ref tag on the property or on one of its parents.
+	Ref *refs.Ref
+	// Line is the line of the values file the property is defined at, 0 if
+	// unknown, eg. for properties documented in comments with +docs:property
+	// and for the properties of subcharts.
+	Line int
+}
+
+// DisplayType returns the type shown in the documentation, which is the
+// inferred format for strings that have one (eg. "duration").
+func (p Property) DisplayType() string {
+	if p.Type == TypeString && p.Format != FormatNone {
+		return p.Format.String()
+	}
+
+	return p.Type.String()
+}
+
+// IsDeprecated returns true if the property is marked with +docs:deprecated.
+func (p Property) IsDeprecated() bool {
+	return p.Description.Tags.GetBool(TagDeprecated)
+}
+
+// DeprecationMessage returns the message of the +docs:deprecated tag, or a
+// generic message if the tag has no value.
+func (p Property) DeprecationMessage() string {
+	if !p.IsDeprecated() {
+		return ""
+	}
+
+	if message := p.Description.Tags.GetString(TagDeprecated); message != "" && message != "true" {
+		return message
+	}
+
+	return "This property is deprecated."
+}
+
+// DefaultDisplay is how the documentation shows the default value of a
+// property, set by the +docs:defaultDisplay tag.
+type DefaultDisplay string
+
+const (
+	// DefaultDisplayAuto shows the default in full, or collapsed if it is
+	// longer than the limit of the renderer.
+	DefaultDisplayAuto      DefaultDisplay = ""
+	DefaultDisplayFull      DefaultDisplay = "full"
+	DefaultDisplayCollapsed DefaultDisplay = "collapsed"
+	DefaultDisplayHidden    DefaultDisplay = "hidden"
+)
+
+// DefaultDisplay returns the value of the +docs:defaultDisplay tag.
+func (p Property) DefaultDisplay() (DefaultDisplay, error) {
+	display := DefaultDisplay(p.Description.Tags.GetString(TagDefaultDisplay))
+	switch display {
+	case DefaultDisplayAuto, DefaultDisplayFull, DefaultDisplayCollapsed, DefaultDisplayHidden:
+		return display, nil
+	default:
+		return "", fmt.Errorf("property %q: invalid %s value %q, expected %q, %q or %q", p.Path, TagDefaultDisplay, display, DefaultDisplayFull, DefaultDisplayCollapsed, DefaultDisplayHidden)
+	}
 }
 
 type Type string
@@ -90,9 +218,16 @@ type Node struct {
 	HeadComments []Comment
 	FootComment  []Comment
 	RawNode      *yaml.Node
+	// Line is the line of the values file the node is defined at, the line
+	// of its key for values of a map.
+	Line int
 }
 
 func Load(filename string, includeHidden bool) (*Document, error) {
+	return load(filename, includeHidden, true)
+}
+
+func load(filename string, includeHidden bool, resolveRefs bool) (*Document, error) {
 	file, err := os.Open(filename)
 	if err != nil {
 		return nil, err
@@ -103,7 +238,7 @@ func Load(filename string, includeHidden bool) (*Document, error) {
 		return nil, err
 	}
 
-	document := Document{Sections: make([]Section, 1)}
+	document := Document{Sections: make([]Section, 1), root: &root}
 	node := Node{
 		RawNode:      &root,
 		HeadComments: parseComments(root.HeadComment),
@@ -112,6 +247,9 @@ func Load(filename string, includeHidden bool) (*Document, error) {
 	// visited prevents alias cycles (CWE-674 stack overflow) and alias fan-out
 	// (OOM) by stopping re-descent into any non-end node already on this walk.
 	visited := map[*yaml.Node]bool{}
+	// objectRefs records the +docs:ref tags found on objects, they are
+	// inherited by all properties nested in the object.
+	objectRefs := map[string]string{}
 	err = walk(node, func(node Node) (bool, error) {
 		comment := pop(&node.HeadComments)
 
@@ -133,6 +271,9 @@ func Load(filename string, includeHidden bool) (*Document, error) {
 		// +docs:property tag (or if they have no values).
 		if !isEndNode(node, comment) {
 			parseCommentsOntoDocument(node.Path.Parent(), &document, []Comment{comment})
+			if ref := comment.Tags.GetString(TagRef); ref != "" {
+				objectRefs[node.Path.String()] = ref
+			}
 			// Only guard recursion into children — end nodes are always safe to
 			// visit multiple times (scalars have no children to cycle through).
 			if visited[node.RawNode] {
@@ -142,17 +283,33 @@ func Load(filename string, includeHidden bool) (*Document, error) {
 			return false, nil
 		}
 
+		typ := getTypeOf(node, comment)
 		sectionIdx := len(document.Sections) - 1
 		document.Sections[sectionIdx].Properties = append(document.Sections[sectionIdx].Properties, Property{
-			Path:        node.Path,
-			Description: comment,
-			Type:        getTypeOf(node, comment),
-			Default:     getDefaultValue(node, comment),
+			Path:          node.Path,
+			Description:   comment,
+			Type:          typ,
+			Format:        getFormatOf(node, comment, typ),
+			Default:       getDefaultValue(node, comment),
+			RequiredWhen:  getPathsOf(comment, TagRequiredWhen),
+			ExclusiveWith: getPathsOf(comment, TagExclusiveWith),
+			See:           getPathsOf(comment, TagSee),
+			Line:          node.Line,
 		})
 
 		return true, nil
 	})
 
+	linkExclusiveProperties(&document)
+
+	if err == nil {
+		err = linkRefs(&document, filepath.Dir(filename), objectRefs)
+	}
+
+	if err == nil && resolveRefs {
+		err = resolveRefDescriptions(&document, includeHidden)
+	}
+
 	return &document, err
 }
 
@@ -231,12 +388,17 @@ func parseCommentsOntoDocument(path paths.Path, document *Document, comments []C
 				continue
 			}
 
+			typ := getTypeOf(parsedNode, comment)
 			sectionIdx := len(document.Sections) - 1
 			document.Sections[sectionIdx].Properties = append(document.Sections[sectionIdx].Properties, Property{
-				Path:        path,
-				Description: comment,
-				Type:        getTypeOf(parsedNode, comment),
-				Default:     "",
+				Path:          path,
+				Description:   comment,
+				Type:          typ,
+				Format:        getFormatOf(parsedNode, comment, typ),
+				Default:       "",
+				RequiredWhen:  getPathsOf(comment, TagRequiredWhen),
+				ExclusiveWith: getPathsOf(comment, TagExclusiveWith),
+				See:           getPathsOf(comment, TagSee),
 			})
 		}
 
@@ -264,6 +426,7 @@ func walk(root Node, fn func(node Node) (bool, error)) error {
 				HeadComments: parseComments(root.RawNode.HeadComment),
 				FootComment:  parseComments(root.RawNode.FootComment),
 				RawNode:      node,
+				Line:         node.Line,
 			}
 
 			if err := walk(n, fn); err != nil {
@@ -280,6 +443,7 @@ func walk(root Node, fn func(node Node) (bool, error)) error {
 				HeadComments: parseComments(keyNode.HeadComment),
 				FootComment:  parseComments(keyNode.FootComment),
 				RawNode:      valueNode,
+				Line:         keyNode.Line,
 			}
 
 			if err := walk(n, fn); err != nil {
@@ -293,6 +457,7 @@ func walk(root Node, fn func(node Node) (bool, error)) error {
 				RawNode:      node,
 				HeadComments: parseComments(node.HeadComment),
 				FootComment:  parseComments(node.FootComment),
+				Line:         node.Line,
 			}
 
 			if err := walk(n, fn); err != nil {
@@ -305,6 +470,7 @@ func walk(root Node, fn func(node Node) (bool, error)) error {
 			HeadComments: parseComments(root.RawNode.HeadComment),
 			FootComment:  parseComments(root.RawNode.FootComment),
 			RawNode:      root.RawNode.Alias,
+			Line:         root.Line,
 		}
 
 		if err := walk(n, fn); err != nil {
@@ -357,6 +523,49 @@ func getDefaultValue(n Node, c Comment) string {
 	return strings.TrimSpace(sb.String())
 }
 
+// getPathsOf parses every value of a path-valued tag, skipping (and warning
+// about) values that are not valid paths.
+func getPathsOf(c Comment, tag string) []paths.Path {
+	var result []paths.Path
+	for _, value := range c.Tags.GetStrings(tag) {
+		path, err := paths.Parse(value)
+		if err != nil {
+			log.Printf("could not parse %s path %q: %s\n", tag, value, err)
+			continue
+		}
+
+		result = append(result, path)
+	}
+
+	return result
+}
+
+// linkExclusiveProperties makes the +docs:exclusiveWith relation symmetric, so
+// that both sides of the relation are documented even if only one of them
+// carries the tag.
+func linkExclusiveProperties(document *Document) {
+	index := map[string]*Property{}
+	for i := range document.Sections {
+		for j := range document.Sections[i].Properties {
+			property := &document.Sections[i].Properties[j]
+			index[property.Path.String()] = property
+		}
+	}
+
+	for _, section := range document.Sections {
+		for _, property := range section.Properties {
+			for _, other := range property.ExclusiveWith {
+				target, ok := index[other.String()]
+				if !ok || slices.ContainsFunc(target.ExclusiveWith, property.Path.Equal) {
+					continue
+				}
+
+				target.ExclusiveWith = append(target.ExclusiveWith, property.Path)
+			}
+		}
+	}
+}
+
 // Remove the last element from a slice and
 // return it
 func pop[T any](s *[]T) T {
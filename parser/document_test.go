@@ -23,6 +23,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/helm-tool/paths"
 )
 
 func writeTemp(t *testing.T, content string) string {
@@ -39,9 +41,9 @@ func writeTemp(t *testing.T, content string) string {
 func TestLoad_SelfReferentialAlias(t *testing.T) {
 	path := writeTemp(t, "a: &a\n  b: *a\n")
 	_, err := Load(path, false)
-	// The call must return (possibly with an error) — a stack overflow is fatal
-	// and would kill the test process before we reach this line.
-	_ = err
+	// A stack overflow is fatal and would kill the test process before we
+	// reach this line.
+	require.NoError(t, err)
 }
 
 // Scaled-down billion-laughs must not OOM.
@@ -57,7 +59,7 @@ e:    [*d,*d,*d,*d,*d,*d,*d,*d,*d,*d]
 `
 	path := writeTemp(t, yaml)
 	_, err := Load(path, false)
-	_ = err
+	require.NoError(t, err)
 }
 
 // Shared anchors must not panic. Note: the visited-set means a non-scalar
@@ -106,6 +108,28 @@ image: nginx
 	assert.Contains(t, paths, "image")
 }
 
+func TestLoad_Lines(t *testing.T) {
+	path := writeTemp(t, `
+image:
+  # The image tag
+  tag: v1
+extraArgs:
+  - --v=2
+# +docs:property
+# webhook: {}
+`)
+	doc, err := Load(path, false)
+	require.NoError(t, err)
+
+	lines := map[string]int{}
+	for _, s := range doc.Sections {
+		for _, p := range s.Properties {
+			lines[p.Path.String()] = p.Line
+		}
+	}
+	assert.Equal(t, map[string]int{"image.tag": 4, "extraArgs[0]": 6, "webhook": 0}, lines)
+}
+
 // A scalar anchor aliased from multiple keys must surface a property at each path.
 func TestLoad_ScalarAnchorAliasedTwice(t *testing.T) {
 	path := writeTemp(t, "x: &s 1\ny: *s\n")
@@ -127,3 +151,38 @@ func TestLoad_MissingFile(t *testing.T) {
 	_, err := Load(filepath.Join(t.TempDir(), "does-not-exist.yaml"), false)
 	require.Error(t, err)
 }
+
+func TestDocument_PropertyOf(t *testing.T) {
+	path := writeTemp(t, `
+image:
+  # The image tag
+  tag: v1
+
+# Labels of the pods
+podLabels: {}
+
+# Extra arguments
+extraArgs: []
+`)
+	doc, err := Load(path, false)
+	require.NoError(t, err)
+
+	tests := map[string]string{
+		"image.tag":                        "image.tag",
+		`podLabels["app.kubernetes.io/x"]`: "podLabels",
+		"extraArgs[2]":                     "extraArgs",
+		"image.tag.x":                      "",
+		"image":                            "",
+		"replicas":                         "",
+	}
+	for setPath, expected := range tests {
+		parsed, err := paths.Parse(setPath)
+		require.NoError(t, err)
+
+		property, ok := doc.PropertyOf(parsed)
+		assert.Equal(t, expected != "", ok, setPath)
+		if ok {
+			assert.Equal(t, expected, property.Path.String(), setPath)
+		}
+	}
+}
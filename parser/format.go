@@ -0,0 +1,179 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"net/mail"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/cert-manager/helm-tool/paths"
+)
+
+// Format refines the type of a string property, it is inferred from the
+// default value of the property.
+type Format string
+
+const (
+	FormatNone     Format = ""
+	FormatDuration Format = "duration"
+	FormatQuantity Format = "quantity"
+	FormatURL      Format = "url"
+	FormatEmail    Format = "email"
+	FormatImage    Format = "image"
+	FormatSemver   Format = "semver"
+)
+
+var (
+	// Go durations, as accepted by time.ParseDuration
+	durationPattern = `^[-+]?(0|([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+)$`
+	// Kubernetes resource quantities, as accepted by resource.ParseQuantity
+	quantityPattern = `^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)(([KMGTPE]i)|[numkMGTPE]|([eE][+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)))?$`
+	// Container image references, the registry must look like a hostname
+	imagePattern = `^(([a-zA-Z0-9-]+\.)+[a-zA-Z0-9-]+(:[0-9]+)?|localhost(:[0-9]+)?)(/[a-z0-9]+([._-][a-z0-9]+)*)+(:[a-zA-Z0-9_][a-zA-Z0-9._-]{0,127})?(@sha256:[a-f0-9]{64})?$`
+	// Semantic versions, optionally prefixed with a "v"
+	semverPattern = `^v?(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)\.(0|[1-9][0-9]*)(-[0-9A-Za-z.-]+)?(\+[0-9A-Za-z.-]+)?$`
+
+	durationRegexp = regexp.MustCompile(durationPattern)
+	quantityRegexp = regexp.MustCompile(quantityPattern)
+	imageRegexp    = regexp.MustCompile(imagePattern)
+	semverRegexp   = regexp.MustCompile(semverPattern)
+
+	// quantitySuffixRegexp matches quantities that cannot be mistaken for
+	// plain numbers or durations.
+	quantitySuffixRegexp = regexp.MustCompile(`([KMGTPE]i|[kMGTPE])$`)
+)
+
+func (f Format) String() string {
+	return string(f)
+}
+
+// SchemaFormat returns the JSON schema "format" for the format, if JSON
+// schema defines one that matches its semantics.
+func (f Format) SchemaFormat() string {
+	switch f {
+	case FormatURL:
+		return "uri"
+	case FormatEmail:
+		return "email"
+	default:
+		return ""
+	}
+}
+
+// SchemaPattern returns a regular expression matching all valid values of the
+// format, for formats that JSON schema has no "format" for.
+func (f Format) SchemaPattern() string {
+	switch f {
+	case FormatDuration:
+		return durationPattern
+	case FormatQuantity:
+		return quantityPattern
+	case FormatImage:
+		return imagePattern
+	case FormatSemver:
+		return semverPattern
+	default:
+		return ""
+	}
+}
+
+// Matches returns true if the value is valid for the format.
+func (f Format) Matches(value string) bool {
+	switch f {
+	case FormatNone:
+		return true
+	case FormatDuration:
+		return durationRegexp.MatchString(value)
+	case FormatQuantity:
+		return quantityRegexp.MatchString(value)
+	case FormatURL:
+		u, err := url.Parse(value)
+		return err == nil && u.Scheme != "" && u.Host != ""
+	case FormatEmail:
+		address, err := mail.ParseAddress(value)
+		return err == nil && address.Address == value
+	case FormatImage:
+		return imageRegexp.MatchString(value)
+	case FormatSemver:
+		return semverRegexp.MatchString(value)
+	default:
+		return false
+	}
+}
+
+// quantityHints are path components that indicate a value is a resource
+// quantity rather than a duration, eg. "100m" is 0.1 CPU, not 100 minutes.
+var quantityHints = []string{"resources", "cpu", "memory", "storage", "size", "limit", "request"}
+
+func getFormatOf(node Node, comment Comment, typ Type) Format {
+	// An explicit type takes precedence over anything we could infer
+	if comment.Tags.GetString(TagType) != "" || typ != TypeString {
+		return FormatNone
+	}
+
+	if node.RawNode == nil || node.RawNode.Kind != yaml.ScalarNode {
+		return FormatNone
+	}
+
+	value := node.RawNode.Value
+	if value == "" {
+		return FormatNone
+	}
+
+	candidates := []Format{FormatDuration, FormatQuantity}
+	if looksLikeQuantity(node.Path, value) {
+		candidates = []Format{FormatQuantity, FormatDuration}
+	}
+	candidates = append(candidates, FormatSemver, FormatURL, FormatEmail, FormatImage)
+
+	for _, format := range candidates {
+		// Plain numbers are valid quantities, but nothing we want to flag
+		if format == FormatQuantity && !quantitySuffixRegexp.MatchString(value) && !looksLikeQuantity(node.Path, value) {
+			continue
+		}
+
+		// A bare zero is a valid duration, but also just a number
+		if format == FormatDuration && strings.Trim(value, "+-") == "0" {
+			continue
+		}
+
+		if format.Matches(value) {
+			return format
+		}
+	}
+
+	return FormatNone
+}
+
+func looksLikeQuantity(path paths.Path, value string) bool {
+	if !quantityRegexp.MatchString(value) {
+		return false
+	}
+
+	lowerPath := strings.ToLower(path.String())
+	for _, hint := range quantityHints {
+		if strings.Contains(lowerPath, hint) {
+			return true
+		}
+	}
+
+	return false
+}
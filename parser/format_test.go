@@ -0,0 +1,103 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_InferFormat(t *testing.T) {
+	path := writeTemp(t, `
+timeout: 60s
+interval: 1h30m
+resources:
+  cpu: 100m
+  memory: 100Mi
+acmeServer: https://acme-v02.api.letsencrypt.org/directory
+email: admin@example.com
+repository: quay.io/jetstack/cert-manager-controller
+version: v1.14.2
+name: cert-manager
+count: "10"
+zero: "0"
+replicas: 1
+# +docs:type=string
+typed: 30s
+`)
+	doc, err := Load(path, false)
+	require.NoError(t, err)
+
+	formats := map[string]Format{}
+	displayTypes := map[string]string{}
+	for _, s := range doc.Sections {
+		for _, p := range s.Properties {
+			formats[p.Path.String()] = p.Format
+			displayTypes[p.Path.String()] = p.DisplayType()
+		}
+	}
+
+	assert.Equal(t, map[string]Format{
+		"timeout":          FormatDuration,
+		"interval":         FormatDuration,
+		"resources.cpu":    FormatQuantity,
+		"resources.memory": FormatQuantity,
+		"acmeServer":       FormatURL,
+		"email":            FormatEmail,
+		"repository":       FormatImage,
+		"version":          FormatSemver,
+		"name":             FormatNone,
+		"count":            FormatNone,
+		"zero":             FormatNone,
+		"replicas":         FormatNone,
+		"typed":            FormatNone,
+	}, formats)
+
+	assert.Equal(t, "duration", displayTypes["timeout"])
+	assert.Equal(t, "string", displayTypes["name"])
+	assert.Equal(t, "number", displayTypes["replicas"])
+	assert.Equal(t, "string", displayTypes["typed"])
+}
+
+func TestFormatMatches(t *testing.T) {
+	tests := []struct {
+		format Format
+		value  string
+		want   bool
+	}{
+		{FormatDuration, "1m30s", true},
+		{FormatDuration, "-1.5h", true},
+		{FormatDuration, "30", false},
+		{FormatQuantity, "1.5Gi", true},
+		{FormatQuantity, "1e3", true},
+		{FormatQuantity, "1Gb", false},
+		{FormatURL, "http://proxy:8080", true},
+		{FormatURL, "proxy:8080", false},
+		{FormatEmail, "Admin <admin@example.com>", false},
+		{FormatImage, "localhost:5000/foo/bar:v1", true},
+		{FormatImage, "docker.io/library/nginx@sha256:0000000000000000000000000000000000000000000000000000000000000000", true},
+		{FormatImage, "nginx", false},
+		{FormatSemver, "1.2.3-alpha.1+build.5", true},
+		{FormatSemver, "1.2", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.format.Matches(tt.value), "%s %q", tt.format, tt.value)
+	}
+}
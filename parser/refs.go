@@ -0,0 +1,153 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"fmt"
+
+	"github.com/cert-manager/helm-tool/paths"
+	"github.com/cert-manager/helm-tool/refs"
+)
+
+// linkRefs sets the Ref of every property that has a +docs:ref tag, or that
+// is nested in an object with a +docs:ref tag.
+func linkRefs(document *Document, dir string, objectRefs map[string]string) error {
+	for i := range document.Sections {
+		for j := range document.Sections[i].Properties {
+			property := &document.Sections[i].Properties[j]
+
+			if value := property.Description.Tags.GetString(TagRef); value != "" {
+				ref, err := refs.Parse(dir, value)
+				if err != nil {
+					return fmt.Errorf("property %q: %w", property.Path, err)
+				}
+				property.Ref = &ref
+				continue
+			}
+
+			for parent := property.Path.Parent(); len(parent) > 0; parent = parent.Parent() {
+				value, ok := objectRefs[parent.String()]
+				if !ok {
+					continue
+				}
+
+				ref, err := refs.Parse(dir, value)
+				if err != nil {
+					return fmt.Errorf("property %q: %w", parent, err)
+				}
+				ref = ref.Child(property.Path[len(parent):])
+				property.Ref = &ref
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// resolveRefDescriptions copies the description of referenced properties
+// from the shared file into properties that have no description of their
+// own, so shared properties only have to be documented once.
+func resolveRefDescriptions(document *Document, includeHidden bool) error {
+	sharedDocuments := map[string]*Document{}
+	sharedSchemas := map[string]map[string]any{}
+
+	for i := range document.Sections {
+		for j := range document.Sections[i].Properties {
+			property := &document.Sections[i].Properties[j]
+			if property.Ref == nil || property.Description.String() != "" {
+				continue
+			}
+
+			location := property.Ref.Location()
+			if _, loaded := sharedDocuments[location]; !loaded && sharedSchemas[location] == nil {
+				kind, raw, err := refs.Load(location)
+				if err != nil {
+					return fmt.Errorf("property %q: %w", property.Path, err)
+				}
+
+				switch kind {
+				case refs.KindSchema:
+					sharedSchemas[location] = raw
+				case refs.KindValues:
+					// References in the shared file are not followed, which
+					// also prevents reference cycles
+					shared, err := load(location, includeHidden, false)
+					if err != nil {
+						return fmt.Errorf("property %q: %w", property.Path, err)
+					}
+					sharedDocuments[location] = shared
+				}
+			}
+
+			var description Comment
+			if shared, ok := sharedDocuments[location]; ok {
+				// Missing values are reported when generating the schema,
+				// they just have no description to share
+				sharedProperty, found := shared.Lookup(property.Ref.ValuesPath())
+				if !found {
+					continue
+				}
+
+				description = sharedProperty.Description
+				if property.Type == TypeUnknown {
+					property.Type = sharedProperty.Type
+				}
+			} else {
+				raw := sharedSchemas[location]
+				pointer, err := refs.SchemaPointer(raw, *property.Ref)
+				if err != nil {
+					continue
+				}
+
+				node, _ := refs.Navigate(raw, pointer)
+				description = schemaDescription(node)
+			}
+
+			// Keep the local tags, they configure this chart's property
+			description.Tags = property.Description.Tags
+			property.Description = description
+		}
+	}
+
+	return nil
+}
+
+// Lookup returns the property documented at the path.
+func (d *Document) Lookup(path paths.Path) (Property, bool) {
+	for _, section := range d.Sections {
+		for _, property := range section.Properties {
+			if property.Path.Equal(path) {
+				return property, true
+			}
+		}
+	}
+
+	return Property{}, false
+}
+
+func schemaDescription(node any) Comment {
+	schema, _ := node.(map[string]any)
+
+	for _, key := range []string{"markdownDescription", "description"} {
+		if text, ok := schema[key].(string); ok && text != "" {
+			return textComment(text)
+		}
+	}
+
+	return Comment{}
+}
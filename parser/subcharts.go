@@ -0,0 +1,261 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/cert-manager/helm-tool/chart"
+	"github.com/cert-manager/helm-tool/paths"
+)
+
+// AddSubcharts appends the documentation of the dependencies listed in the
+// Chart.yaml of the chart to the document, as one or more sections per
+// dependency. The properties of a dependency are nested under its name (or
+// alias), except for global properties. Values imported into the parent chart
+// with import-values mention the path they are imported to. Properties already
+// documented by the parent chart are not repeated.
+func AddSubcharts(document *Document, chartDir string, includeHidden bool) error {
+	metadata, err := chart.Load(chartDir)
+	if err != nil {
+		return err
+	}
+
+	documented := map[string]bool{}
+	for _, section := range document.Sections {
+		for _, property := range section.Properties {
+			documented[property.Path.String()] = true
+		}
+	}
+
+	for _, dependency := range metadata.Dependencies {
+		subchartMetadata, subchartDocument, err := loadSubchart(chartDir, dependency, includeHidden)
+		if err != nil {
+			return fmt.Errorf("could not load values of dependency %q: %w", dependency.Name, err)
+		}
+
+		sections, err := subchartSections(dependency, subchartMetadata, subchartDocument, documented)
+		if err != nil {
+			return fmt.Errorf("dependency %q: %w", dependency.Name, err)
+		}
+
+		document.Sections = append(document.Sections, sections...)
+	}
+
+	return nil
+}
+
+// loadSubchart reads the Chart.yaml and the documented values of a
+// dependency. A dependency without a values.yaml, or with an empty one, has
+// no properties.
+func loadSubchart(chartDir string, dependency chart.Dependency, includeHidden bool) (*chart.Metadata, *Document, error) {
+	subchart, err := chart.OpenSubchart(chartDir, dependency)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer subchart.Close()
+
+	metadata, err := chart.Load(subchart.Dir)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	valuesFile := filepath.Join(subchart.Dir, chart.ValuesFile)
+	if _, err := os.Stat(valuesFile); os.IsNotExist(err) {
+		return metadata, &Document{Sections: make([]Section, 1)}, nil
+	}
+
+	// An empty (or comment only) values.yaml has no document at all
+	document, err := Load(valuesFile, includeHidden)
+	if errors.Is(err, io.EOF) {
+		return metadata, &Document{Sections: make([]Section, 1)}, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return metadata, document, nil
+}
+
+// subchartSections moves the sections of a subchart document to the paths
+// they are set at in the parent chart. The first section, named after the
+// dependency, describes where the subchart values go and which condition
+// enables the subchart.
+func subchartSections(dependency chart.Dependency, metadata *chart.Metadata, document *Document, documented map[string]bool) ([]Section, error) {
+	key := paths.Path{}.WithProperty(dependency.Key())
+
+	type importedPath struct {
+		child  paths.Path
+		parent paths.Path
+	}
+	var imports []importedPath
+	for _, importValue := range dependency.ImportValues {
+		child, err := paths.Parse(importValue.Child)
+		if err != nil {
+			return nil, fmt.Errorf("invalid import-values child %q: %w", importValue.Child, err)
+		}
+
+		var parent paths.Path
+		if importValue.Parent != "" {
+			if parent, err = paths.Parse(importValue.Parent); err != nil {
+				return nil, fmt.Errorf("invalid import-values parent %q: %w", importValue.Parent, err)
+			}
+		}
+
+		imports = append(imports, importedPath{child: child, parent: parent})
+	}
+
+	// parentPath returns the path a value of the subchart is set at in the
+	// parent chart.
+	parentPath := func(path paths.Path) paths.Path {
+		if name, _ := paths.PropertyName(path[0]); name == "global" {
+			return path
+		}
+
+		rebased, _ := path.Rebase(nil, key)
+		return rebased
+	}
+	parentPaths := func(list []paths.Path) []paths.Path {
+		var result []paths.Path
+		for _, path := range list {
+			result = append(result, parentPath(path))
+		}
+		return result
+	}
+
+	// importNote describes where import-values copies a value of the
+	// subchart to in the parent values, if it does.
+	importNote := func(path paths.Path) (Comment, bool) {
+		var targets []string
+		for _, imported := range imports {
+			if rebased, ok := path.Rebase(imported.child, imported.parent); ok && len(rebased) > 0 {
+				targets = append(targets, rebased.String())
+			}
+		}
+
+		if len(targets) == 0 {
+			return Comment{}, false
+		}
+
+		return textComment(fmt.Sprintf("Imported into the values of the parent chart as `%s`.", strings.Join(targets, "`, `"))), true
+	}
+
+	var sections []Section
+	for i, section := range document.Sections {
+		name := dependency.Key()
+		if section.Name != "" {
+			name += ": " + section.Name
+		}
+
+		moved := Section{Name: name, Description: section.Description}
+		if i == 0 {
+			moved.Description = subchartDescription(dependency, metadata, section.Description)
+		}
+
+		for _, property := range section.Properties {
+			if len(property.Path) == 0 {
+				continue
+			}
+
+			note, imported := importNote(property.Path)
+			property.Path = parentPath(property.Path)
+			if documented[property.Path.String()] {
+				continue
+			}
+			documented[property.Path.String()] = true
+
+			if imported {
+				property.Description.Segments = append(slices.Clone(property.Description.Segments), note.Segments...)
+			}
+
+			property.Line = 0
+			property.RequiredWhen = parentPaths(property.RequiredWhen)
+			property.ExclusiveWith = parentPaths(property.ExclusiveWith)
+			property.See = parentPaths(property.See)
+			moved.Properties = append(moved.Properties, property)
+		}
+
+		if i == 0 || len(moved.Properties) > 0 {
+			sections = append(sections, moved)
+		}
+	}
+
+	// Helm installs the subchart unless the condition is false, make sure
+	// the documentation shows the property to disable it. It defaults to
+	// true, as an unset condition enables the subchart.
+	for _, condition := range conditionPaths(dependency) {
+		if documented[condition] {
+			continue
+		}
+
+		path, err := paths.Parse(condition)
+		if err != nil {
+			return nil, fmt.Errorf("invalid condition %q: %w", condition, err)
+		}
+
+		documented[condition] = true
+		sections[0].Properties = append([]Property{{
+			Path:        path,
+			Description: textComment(fmt.Sprintf("Whether to install the %s subchart.", dependency.Name)),
+			Type:        TypeBool,
+			Default:     "true",
+		}}, sections[0].Properties...)
+	}
+
+	return sections, nil
+}
+
+// subchartDescription returns the description of the first section of a
+// subchart, followed by the description of its default section.
+func subchartDescription(dependency chart.Dependency, metadata *chart.Metadata, description Comment) Comment {
+	lines := []string{fmt.Sprintf("Values of the `%s` subchart, set under `%s`.", dependency.Name, dependency.Key())}
+	if metadata.Description != "" {
+		lines = append(lines, metadata.Description)
+	}
+
+	switch conditions := conditionPaths(dependency); len(conditions) {
+	case 0:
+	case 1:
+		lines = append(lines, fmt.Sprintf("The subchart is only installed when `%s` is true.", conditions[0]))
+	default:
+		lines = append(lines, fmt.Sprintf("The subchart is only installed when the first of `%s` that is set is true.", strings.Join(conditions, "`, `")))
+	}
+
+	comment := textComment(strings.Join(lines, " "))
+	comment.Segments = append(comment.Segments, description.Segments...)
+	comment.Tags = description.Tags
+	return comment
+}
+
+// conditionPaths returns the paths of the comma separated condition of the
+// dependency, Helm uses the first one that is set in the values.
+func conditionPaths(dependency chart.Dependency) []string {
+	var conditions []string
+	for _, condition := range strings.Split(dependency.Condition, ",") {
+		if condition = strings.TrimSpace(condition); condition != "" {
+			conditions = append(conditions, condition)
+		}
+	}
+
+	return conditions
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+}
+
+func TestAddSubcharts(t *testing.T) {
+	chartDir := t.TempDir()
+	writeFiles(t, chartDir, map[string]string{
+		"Chart.yaml": `
+name: umbrella
+dependencies:
+- name: redis
+  alias: cache
+  condition: cache.enabled
+- name: lib
+  import-values:
+  - data
+  - child: settings
+    parent: libSettings
+`,
+		"values.yaml": `
+replicas: 1
+cache:
+  port: 6380
+`,
+		"charts/redis/Chart.yaml": "name: redis\ndescription: An in-memory store.\n",
+		"charts/redis/values.yaml": `
+global:
+  imageRegistry: ""
+port: 6379
+
+# +docs:section=Persistence
+
+persistence:
+  # +docs:see=port
+  size: 1Gi
+`,
+		"charts/lib/Chart.yaml": "name: lib\n",
+		"charts/lib/values.yaml": `
+exports:
+  data:
+    logLevel: info
+settings:
+  timeout: 30s
+name: lib
+`,
+	})
+
+	document, err := Load(filepath.Join(chartDir, "values.yaml"), false)
+	require.NoError(t, err)
+	require.NoError(t, AddSubcharts(document, chartDir, false))
+
+	sections := map[string][]string{}
+	var names []string
+	for _, section := range document.Sections {
+		names = append(names, section.Name)
+		for _, property := range section.Properties {
+			sections[section.Name] = append(sections[section.Name], property.Path.String())
+		}
+	}
+
+	assert.Equal(t, []string{"", "cache", "cache: Persistence", "lib"}, names)
+	assert.Equal(t, map[string][]string{
+		"":                   {"replicas", "cache.port"},
+		"cache":              {"cache.enabled", "global.imageRegistry"},
+		"cache: Persistence": {"cache.persistence.size"},
+		"lib":                {"lib.exports.data.logLevel", "lib.settings.timeout", "lib.name"},
+	}, sections)
+
+	cache := document.Sections[1]
+	assert.Equal(t, "Values of the `redis` subchart, set under `cache`. An in-memory store. The subchart is only installed when `cache.enabled` is true.", cache.Description.Segments[0].String())
+	assert.Equal(t, TypeBool, cache.Properties[0].Type)
+	assert.Equal(t, "true", cache.Properties[0].Default)
+	assert.Equal(t, "cache.port", document.Sections[2].Properties[0].See[0].String())
+
+	// Imported values keep their path under the dependency, and mention the
+	// path they are imported to
+	lib := document.Sections[3]
+	descriptions := map[string]string{}
+	for _, property := range lib.Properties {
+		for _, segment := range property.Description.Segments {
+			descriptions[property.Path.String()] = segment.String()
+		}
+	}
+	assert.Equal(t, "Imported into the values of the parent chart as `logLevel`.", descriptions["lib.exports.data.logLevel"])
+	assert.Equal(t, "Imported into the values of the parent chart as `libSettings.timeout`.", descriptions["lib.settings.timeout"])
+	assert.Empty(t, descriptions["lib.name"])
+}
+
+func TestAddSubcharts_EmptyValues(t *testing.T) {
+	chartDir := t.TempDir()
+	writeFiles(t, chartDir, map[string]string{
+		"Chart.yaml":            "name: umbrella\ndependencies:\n- name: sub\n- name: blank\n",
+		"values.yaml":           "foo: bar\n",
+		"charts/sub/Chart.yaml": "name: sub\n",
+		"charts/sub/values.yaml": `# Default values for sub.
+# This is a YAML-formatted file.
+`,
+		"charts/blank/Chart.yaml":  "name: blank\n",
+		"charts/blank/values.yaml": "",
+	})
+
+	document, err := Load(filepath.Join(chartDir, "values.yaml"), false)
+	require.NoError(t, err)
+	require.NoError(t, AddSubcharts(document, chartDir, false))
+
+	require.Len(t, document.Sections, 3)
+	assert.Equal(t, "sub", document.Sections[1].Name)
+	assert.Empty(t, document.Sections[1].Properties)
+	assert.Equal(t, "blank", document.Sections[2].Name)
+	assert.Empty(t, document.Sections[2].Properties)
+}
+
+func TestAddSubcharts_Missing(t *testing.T) {
+	chartDir := t.TempDir()
+	writeFiles(t, chartDir, map[string]string{
+		"Chart.yaml":  "name: umbrella\ndependencies:\n- name: missing\n",
+		"values.yaml": "foo: bar\n",
+	})
+
+	document, err := Load(filepath.Join(chartDir, "values.yaml"), false)
+	require.NoError(t, err)
+	require.ErrorContains(t, AddSubcharts(document, chartDir, false), "helm dependency build")
+}
@@ -49,6 +49,30 @@ func (t tags) GetBool(key string) bool {
 	return result
 }
 
+// WithPrefix returns the last value of every tag whose key starts with
+// prefix, keyed by the remainder of the key.
+func (t tags) WithPrefix(prefix string) map[string]string {
+	result := map[string]string{}
+	for key := range t {
+		if name, ok := strings.CutPrefix(key, prefix); ok && name != "" {
+			result[name] = t.GetString(key)
+		}
+	}
+
+	return result
+}
+
+func (t tags) GetStrings(key string) []string {
+	var result []string
+	for _, value := range t[key] {
+		if value != "" {
+			result = append(result, value)
+		}
+	}
+
+	return result
+}
+
 func (t tags) GetString(key string) string {
 	result := ""
 	for _, value := range t[key] {
@@ -18,6 +18,7 @@ package paths
 
 import (
 	"bytes"
+	"cmp"
 	"fmt"
 	"io"
 	"strconv"
@@ -51,6 +52,20 @@ func IsArrayPathComponent(pc pathComponent) bool {
 	return ok
 }
 
+// PropertyName returns the unquoted name of a map path component, it returns
+// false for array path components.
+func PropertyName(pc pathComponent) (string, bool) {
+	name, ok := pc.(mapPathComponent)
+	return string(name), ok
+}
+
+// ArrayIndex returns the index of an array path component, it returns false
+// for map path components.
+func ArrayIndex(pc pathComponent) (int, bool) {
+	idx, ok := pc.(arrayPathComponent)
+	return int(idx), ok
+}
+
 func SegmentString(pc pathComponent) string {
 	sb := strings.Builder{}
 	pc.Append(0, &sb)
@@ -160,6 +175,16 @@ func (p Path) Expand(other Path, n int) Path {
 	return append(Path{}, other[:len(p)+n]...)
 }
 
+// Rebase replaces the prefix of the path with another prefix. It returns
+// false if the path does not start with the prefix.
+func (p Path) Rebase(prefix Path, newPrefix Path) (Path, bool) {
+	if !prefix.IsSubPathOf(p) {
+		return nil, false
+	}
+
+	return append(append(Path{}, newPrefix...), p[len(prefix):]...), true
+}
+
 func (p Path) Equal(other Path) bool {
 	if len(p) != len(other) {
 		return false
@@ -168,6 +193,28 @@ func (p Path) Equal(other Path) bool {
 	return p.IsSubPathOf(other)
 }
 
+// Compare orders paths by their components, property names alphabetically
+// and array indices numerically, with parents before their children. It
+// returns -1, 0 or +1 like strings.Compare.
+func (p Path) Compare(other Path) int {
+	for i := 0; i < len(p) && i < len(other); i++ {
+		switch a, b := p[i], other[i]; {
+		case a == b:
+			continue
+		case IsArrayPathComponent(a) && IsArrayPathComponent(b):
+			return cmp.Compare(a.(arrayPathComponent), b.(arrayPathComponent))
+		case IsArrayPathComponent(a):
+			return -1
+		case IsArrayPathComponent(b):
+			return 1
+		default:
+			return strings.Compare(string(a.(mapPathComponent)), string(b.(mapPathComponent)))
+		}
+	}
+
+	return cmp.Compare(len(p), len(other))
+}
+
 func (p Path) String() string {
 	sb := strings.Builder{}
 	for i, part := range p {
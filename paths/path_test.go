@@ -18,6 +18,7 @@ package paths
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -103,3 +104,172 @@ func TestWithIndex(t *testing.T) {
 		t.Errorf("path2.String() = %v, expected %v", path2.String(), "foo.bar.aaaa[1]")
 	}
 }
+
+func TestSetKey(t *testing.T) {
+	tests := []struct {
+		path     Path
+		expected string
+	}{
+		{Path{}.WithProperty("webhook").WithProperty("timeoutSeconds"), `webhook.timeoutSeconds`},
+		{Path{}.WithProperty("podLabels").WithProperty("app.kubernetes.io/name"), `podLabels.app\.kubernetes\.io/name`},
+		{Path{}.WithProperty("extraArgs").WithIndex(0), `extraArgs[0]`},
+		{Path{}.WithProperty("list").WithIndex(1).WithProperty("a,b=c"), `list[1].a\,b\=c`},
+	}
+
+	for _, test := range tests {
+		if got := test.path.SetKey(); got != test.expected {
+			t.Errorf("SetKey() of %q = %q, expected %q", test.path, got, test.expected)
+		}
+	}
+}
+
+func TestParseSetKey(t *testing.T) {
+	tests := []struct {
+		key      string
+		expected Path
+		err      bool
+	}{
+		{`webhook.timeoutSeconds`, Path{}.WithProperty("webhook").WithProperty("timeoutSeconds"), false},
+		{`podLabels.app\.kubernetes\.io/name`, Path{}.WithProperty("podLabels").WithProperty("app.kubernetes.io/name"), false},
+		{`list[1][2].a\,b\=c`, Path{}.WithProperty("list").WithIndex(1).WithIndex(2).WithProperty("a,b=c"), false},
+		{`a..b`, nil, true},
+		{`a[x]`, nil, true},
+		{`a[-1]`, nil, true},
+		{`a[0]b`, nil, true},
+		{`a=b`, nil, true},
+		{`a.`, nil, true},
+	}
+
+	for _, test := range tests {
+		path, err := ParseSetKey(test.key)
+		if (err != nil) != test.err {
+			t.Errorf("ParseSetKey(%q) error = %v, expected error %v", test.key, err, test.err)
+			continue
+		}
+
+		if !test.err && (!path.Equal(test.expected) || path.SetKey() != test.key) {
+			t.Errorf("ParseSetKey(%q) = %q, expected %q", test.key, path, test.expected)
+		}
+	}
+}
+
+func TestParseSet(t *testing.T) {
+	tests := []struct {
+		expression string
+		json       bool
+		expected   []string
+		err        bool
+	}{
+		{`image.tag=v1`, false, []string{"image.tag"}, false},
+		{`a=1,b\.c=x\,y,extraArgs={--v=2,--x},d[0]=`, false, []string{"a", `["b.c"]`, "extraArgs", "d[0]"}, false},
+		{`a`, false, nil, true},
+		{`a,b=1`, false, nil, true},
+		{`a={x,y`, false, nil, true},
+		{`a={x}y`, false, nil, true},
+		{`tolerations=[{"key":"a","operator":"Exists"}],podLabels={"a,b":"c"}`, true, []string{"tolerations", "podLabels"}, false},
+		{`a={"b":}`, true, nil, true},
+		{`a=1 2`, true, nil, true},
+	}
+
+	for _, test := range tests {
+		parse := ParseSet
+		if test.json {
+			parse = ParseSetJSON
+		}
+
+		result, err := parse(test.expression)
+		if (err != nil) != test.err {
+			t.Errorf("parsing %q: error = %v, expected error %v", test.expression, err, test.err)
+			continue
+		}
+
+		var got []string
+		for _, path := range result {
+			got = append(got, path.String())
+		}
+		if strings.Join(got, " ") != strings.Join(test.expected, " ") {
+			t.Errorf("parsing %q = %q, expected %q", test.expression, got, test.expected)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	sorted := []Path{
+		Path{}.WithProperty("image"),
+		Path{}.WithProperty("image").WithProperty("tag"),
+		Path{}.WithProperty("imagePullSecrets"),
+		Path{}.WithProperty("list").WithIndex(2),
+		Path{}.WithProperty("list").WithIndex(10),
+		Path{}.WithProperty("list").WithProperty("a"),
+	}
+
+	for i, a := range sorted {
+		for j, b := range sorted {
+			expected := 0
+			if i < j {
+				expected = -1
+			} else if i > j {
+				expected = 1
+			}
+
+			if got := a.Compare(b); got != expected {
+				t.Errorf("%q.Compare(%q) = %d, expected %d", a, b, got, expected)
+			}
+		}
+	}
+}
+
+func TestRebase(t *testing.T) {
+	exports := Path{}.WithProperty("exports").WithProperty("data")
+	key := Path{}.WithProperty("cert-manager")
+
+	tests := []struct {
+		path      Path
+		prefix    Path
+		newPrefix Path
+		expected  string
+		ok        bool
+	}{
+		{exports.WithProperty("a").WithIndex(0), exports, nil, "a[0]", true},
+		{exports, exports, key, "cert-manager", true},
+		{Path{}.WithProperty("installCRDs"), nil, key, "cert-manager.installCRDs", true},
+		{Path{}.WithProperty("exports").WithProperty("other"), exports, nil, "", false},
+	}
+
+	for _, test := range tests {
+		rebased, ok := test.path.Rebase(test.prefix, test.newPrefix)
+		if ok != test.ok || rebased.String() != test.expected {
+			t.Errorf("%q.Rebase(%q, %q) = %q, %v, expected %q, %v", test.path, test.prefix, test.newPrefix, rebased, ok, test.expected, test.ok)
+		}
+	}
+}
+
+func TestCompileGlob(t *testing.T) {
+	tests := []struct {
+		glob    string
+		path    string
+		matches bool
+	}{
+		{"webhook.*", "webhook.url", true},
+		{"webhook.*", "webhook.url.path", false},
+		{"webhook.**", "webhook.url.path", true},
+		{"extraObjects[*].**", "extraObjects[*].kind", true},
+		{"*.image", "image", false},
+		{"image.?ag", "image.tag", true},
+	}
+
+	for _, test := range tests {
+		glob, err := CompileGlob(test.glob)
+		if err != nil {
+			t.Fatalf("CompileGlob(%q) failed: %s", test.glob, err)
+		}
+
+		if matches := glob.MatchString(test.path); matches != test.matches {
+			t.Errorf("glob %q matching %q = %v, expected %v", test.glob, test.path, matches, test.matches)
+		}
+	}
+
+	if _, err := CompileGlob(""); err == nil {
+		t.Errorf("expected an error for an empty glob")
+	}
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package paths
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// setKeyEscaper escapes the characters that have a meaning in the keys of
+// Helm's --set flag.
+var setKeyEscaper = strings.NewReplacer(`\`, `\\`, `.`, `\.`, `[`, `\[`, `,`, `\,`, `=`, `\=`)
+
+// SetKey returns the path in the syntax of Helm's --set flag, eg.
+// podLabels.app\.kubernetes\.io/name or extraArgs[0].
+func (p Path) SetKey() string {
+	var sb strings.Builder
+	for i, component := range p {
+		switch component := component.(type) {
+		case mapPathComponent:
+			if i > 0 {
+				sb.WriteByte('.')
+			}
+			sb.WriteString(setKeyEscaper.Replace(string(component)))
+		case arrayPathComponent:
+			fmt.Fprintf(&sb, "[%d]", component)
+		}
+	}
+
+	return sb.String()
+}
+
+// ParseSetKey parses a key in the syntax of Helm's --set flag, the reverse of
+// SetKey, eg. podLabels.app\.kubernetes\.io/name or extraArgs[0].
+func ParseSetKey(key string) (Path, error) {
+	path, rest, err := parseSetKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if rest != "" {
+		return nil, fmt.Errorf("key %q: unexpected %q", key, rest)
+	}
+
+	return path, nil
+}
+
+// ParseSet returns the paths set by an expression of Helm's --set or
+// --set-string flag, eg. image.tag=v1,extraArgs={--v=2,--enable-x}.
+func ParseSet(expression string) ([]Path, error) {
+	var result []Path
+	for rest := expression; rest != ""; {
+		path, value, err := parseSetKey(rest)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(value, "=") {
+			return nil, fmt.Errorf("key %q has no value", path.SetKey())
+		}
+
+		rest, err = skipSetValue(value[1:])
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", path.SetKey(), err)
+		}
+		result = append(result, path)
+	}
+
+	return result, nil
+}
+
+// ParseSetJSON returns the paths set by an expression of Helm's --set-json
+// flag, eg. tolerations=[{"operator":"Exists"}],podLabels={"app":"a"}.
+func ParseSetJSON(expression string) ([]Path, error) {
+	var result []Path
+	for rest := expression; rest != ""; {
+		path, value, err := parseSetKey(rest)
+		if err != nil {
+			return nil, err
+		}
+		if !strings.HasPrefix(value, "=") {
+			return nil, fmt.Errorf("key %q has no value", path.SetKey())
+		}
+
+		decoder := json.NewDecoder(strings.NewReader(value[1:]))
+		var v any
+		if err := decoder.Decode(&v); err != nil {
+			return nil, fmt.Errorf("key %q: invalid JSON value: %w", path.SetKey(), err)
+		}
+
+		rest = strings.TrimLeft(value[1+int(decoder.InputOffset()):], " \t\n")
+		if rest != "" && !strings.HasPrefix(rest, ",") {
+			return nil, fmt.Errorf("key %q: unexpected %q after the JSON value", path.SetKey(), rest)
+		}
+		rest = strings.TrimPrefix(rest, ",")
+		result = append(result, path)
+	}
+
+	return result, nil
+}
+
+// parseSetKey parses the key at the start of the expression, it returns the
+// rest of the expression starting with the "=" of the value.
+func parseSetKey(expression string) (Path, string, error) {
+	var path Path
+	var name strings.Builder
+	afterIndex := false
+
+	runes := []rune(expression)
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; r {
+		case '\\':
+			if i+1 == len(runes) {
+				return nil, "", fmt.Errorf("key %q: unexpected end after \\", expression)
+			}
+			i++
+			name.WriteRune(runes[i])
+		case '.', '=', '[':
+			if !afterIndex {
+				if name.Len() == 0 {
+					return nil, "", fmt.Errorf("key %q: unexpected empty name", expression)
+				}
+				path = append(path, mapPathComponent(name.String()))
+				name.Reset()
+			} else if name.Len() > 0 {
+				return nil, "", fmt.Errorf("key %q: unexpected %q after an array index", expression, name.String())
+			}
+			afterIndex = false
+
+			switch r {
+			case '=':
+				return path, string(runes[i:]), nil
+			case '[':
+				end := i + 1
+				for end < len(runes) && runes[end] != ']' {
+					end++
+				}
+				if end == len(runes) {
+					return nil, "", fmt.Errorf("key %q: unterminated array index", expression)
+				}
+
+				idx, err := strconv.Atoi(string(runes[i+1 : end]))
+				if err != nil || idx < 0 {
+					return nil, "", fmt.Errorf("key %q: invalid array index %q", expression, string(runes[i+1:end]))
+				}
+				path = append(path, arrayPathComponent(idx))
+				afterIndex = true
+				i = end
+			}
+		case ',':
+			return nil, "", fmt.Errorf("key %q has no value", string(runes[:i]))
+		default:
+			if afterIndex {
+				return nil, "", fmt.Errorf("key %q: unexpected %q after an array index", expression, r)
+			}
+			name.WriteRune(r)
+		}
+	}
+
+	if name.Len() > 0 {
+		path = append(path, mapPathComponent(name.String()))
+	} else if !afterIndex {
+		return nil, "", fmt.Errorf("key %q: unexpected empty name", expression)
+	}
+
+	return path, "", nil
+}
+
+// skipSetValue skips a --set value, a {} list or a scalar, and the comma
+// following it.
+func skipSetValue(value string) (string, error) {
+	list := strings.HasPrefix(value, "{")
+	runes := []rune(value)
+	for i := 0; i < len(runes); i++ {
+		switch runes[i] {
+		case '\\':
+			i++
+		case '}':
+			if list {
+				list = false
+				if i+1 < len(runes) && runes[i+1] != ',' {
+					return "", fmt.Errorf("unexpected %q after a list", string(runes[i+1:]))
+				}
+			}
+		case ',':
+			if !list {
+				return string(runes[i+1:]), nil
+			}
+		}
+	}
+
+	if list {
+		return "", fmt.Errorf("unterminated list %q", value)
+	}
+
+	return "", nil
+}
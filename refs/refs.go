@@ -0,0 +1,231 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package refs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/cert-manager/helm-tool/paths"
+)
+
+// Ref points to the shared definition of a property, either in a JSON schema
+// file or in a values file, using the syntax <file>#<json pointer>.
+type Ref struct {
+	// Dir is the directory of the values file containing the reference, the
+	// file is resolved relative to it.
+	Dir string
+	// File is the referenced file, as written in the tag.
+	File string
+	// Pointer is the JSON pointer into the referenced file, without the
+	// leading '#'. For values files it points to a value, eg. /image.
+	Pointer string
+	// Path is the path of the property below the pointer, set for properties
+	// that inherit the reference from one of their parents.
+	Path paths.Path
+}
+
+type Kind string
+
+const (
+	KindSchema Kind = "schema"
+	KindValues Kind = "values"
+)
+
+// Parse parses the value of a +docs:ref tag.
+func Parse(dir string, value string) (Ref, error) {
+	file, pointer, _ := strings.Cut(value, "#")
+	if file == "" {
+		return Ref{}, fmt.Errorf("reference %q has no file", value)
+	}
+
+	if pointer != "" && !strings.HasPrefix(pointer, "/") {
+		return Ref{}, fmt.Errorf("reference %q has an invalid JSON pointer, it must start with '/'", value)
+	}
+
+	return Ref{Dir: dir, File: file, Pointer: pointer}, nil
+}
+
+// Child returns the reference for a property below the referenced one.
+func (r Ref) Child(path paths.Path) Ref {
+	r.Path = append(append(paths.Path{}, r.Path...), path...)
+	return r
+}
+
+// Location returns the path of the referenced file.
+func (r Ref) Location() string {
+	if filepath.IsAbs(r.File) {
+		return r.File
+	}
+
+	return filepath.Join(r.Dir, r.File)
+}
+
+func (r Ref) String() string {
+	if len(r.Path) == 0 {
+		return r.File + "#" + r.Pointer
+	}
+
+	return r.File + "#" + r.Pointer + " (" + r.Path.String() + ")"
+}
+
+// ValuesPath returns the path of the referenced value, for references into
+// values files.
+func (r Ref) ValuesPath() paths.Path {
+	path := paths.Path{}
+	if r.Pointer == "" {
+		return append(path, r.Path...)
+	}
+
+	for _, token := range strings.Split(r.Pointer[1:], "/") {
+		token = unescape(token)
+		if idx, err := strconv.Atoi(token); err == nil {
+			path = path.WithIndex(idx)
+		} else {
+			path = path.WithProperty(token)
+		}
+	}
+
+	return append(path, r.Path...)
+}
+
+// Load reads the referenced file and determines whether it is a JSON schema
+// or a values file. JSON files are always schemas, YAML files are schemas if
+// they have a top-level $schema, $defs or definitions key.
+func Load(location string) (Kind, map[string]any, error) {
+	contents, err := os.ReadFile(location)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var document map[string]any
+	if strings.EqualFold(filepath.Ext(location), ".json") {
+		if err := json.Unmarshal(contents, &document); err != nil {
+			return "", nil, fmt.Errorf("could not parse %q: %w", location, err)
+		}
+
+		return KindSchema, document, nil
+	}
+
+	if err := yaml.Unmarshal(contents, &document); err != nil {
+		return "", nil, fmt.Errorf("could not parse %q: %w", location, err)
+	}
+
+	for _, key := range []string{"$schema", "$defs", "definitions"} {
+		if _, ok := document[key]; ok {
+			return KindSchema, document, nil
+		}
+	}
+
+	return KindValues, document, nil
+}
+
+// Navigate returns the node of the document the JSON pointer points to.
+func Navigate(document any, pointer string) (any, bool) {
+	if pointer == "" {
+		return document, true
+	}
+
+	node := document
+	for _, token := range strings.Split(pointer[1:], "/") {
+		token = unescape(token)
+		switch value := node.(type) {
+		case map[string]any:
+			child, ok := value[token]
+			if !ok {
+				return nil, false
+			}
+			node = child
+		case []any:
+			idx, err := strconv.Atoi(token)
+			if err != nil || idx < 0 || idx >= len(value) {
+				return nil, false
+			}
+			node = value[idx]
+		default:
+			return nil, false
+		}
+	}
+
+	return node, true
+}
+
+// SchemaPointer returns the JSON pointer to the schema of the referenced
+// property in a schema document, descending into "properties" and "items"
+// for the Path of the reference and following local $refs on the way.
+func SchemaPointer(document map[string]any, ref Ref) (string, error) {
+	pointer := ref.Pointer
+	node, ok := Navigate(document, pointer)
+	if !ok {
+		return "", fmt.Errorf("%s: pointer not found", ref)
+	}
+
+	for i, component := range ref.Path {
+		// Follow local references, the properties are defined at their target
+		for range 32 {
+			target, isRef := localRef(node)
+			if !isRef {
+				break
+			}
+
+			if node, ok = Navigate(document, target); !ok {
+				return "", fmt.Errorf("%s: reference %q not found", ref, "#"+target)
+			}
+			pointer = target
+		}
+
+		if name, isProperty := paths.PropertyName(component); isProperty {
+			pointer += "/properties/" + escape(name)
+		} else {
+			pointer += "/items"
+		}
+
+		if node, ok = Navigate(document, pointer); !ok {
+			return "", fmt.Errorf("%s: property %q not found", ref, ref.Path[:i+1])
+		}
+	}
+
+	return pointer, nil
+}
+
+func localRef(node any) (string, bool) {
+	value, ok := node.(map[string]any)
+	if !ok {
+		return "", false
+	}
+
+	ref, ok := value["$ref"].(string)
+	if !ok || !strings.HasPrefix(ref, "#") {
+		return "", false
+	}
+
+	return ref[1:], true
+}
+
+func escape(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+func unescape(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/helm-tool/chart"
+)
+
+func TestBadgeURL(t *testing.T) {
+	assert.Equal(t, "https://img.shields.io/badge/Version-v1.0.0--rc_1-informational?style=flat-square", badgeURL("Version", "v1.0.0-rc 1", "informational"))
+	assert.Equal(t, "https://img.shields.io/badge/Kubernetes-%3E=1.22.0--0-informational?style=flat-square", badgeURL("Kubernetes", ">=1.22.0-0", "informational"))
+}
+
+func TestRender_ChartHeader(t *testing.T) {
+	document := loadDocument(t, `
+# The number of replicas
+replicas: 1
+`)
+
+	metadata := &chart.Metadata{
+		Name:        "demo",
+		Version:     "1.2.3",
+		Description: "A demo chart",
+		Deprecated:  true,
+		Dependencies: []chart.Dependency{
+			{Name: "redis", Alias: "cache", Version: "1.x", Repository: "https://charts.example.com", Condition: "cache.enabled"},
+		},
+		Maintainers: []chart.Maintainer{
+			{Name: "Jane", Email: "jane@example.com", URL: "https://jane.example.com"},
+			{Name: "John"},
+		},
+	}
+
+	rendered, err := RenderWithOptions("chart-header", document, Options{Chart: metadata})
+	require.NoError(t, err)
+	assert.Equal(t, "# demo\n\n"+
+		"![Version: 1\\.2.3](https://img.shields.io/badge/Version-1.2.3-informational?style=flat-square) "+
+		"![Deprecated](https://img.shields.io/badge/Status-deprecated-critical?style=flat-square)\n\n"+
+		"A demo chart\n\n"+
+		"## Dependencies\n\n"+
+		"| Name | Version | Repository | Condition |\n"+
+		"|------|---------|------------|-----------|\n"+
+		"| redis (as `cache`) | `1.x` | `https://charts.example.com` | `cache.enabled` |\n\n"+
+		"## Maintainers\n\n"+
+		"- [Jane](https://jane.example.com) <jane@example.com>\n"+
+		"- John", rendered)
+
+	_, err = Render("chart-header", document)
+	require.ErrorContains(t, err, "use --chart")
+}
+
+func TestRender_ChartData(t *testing.T) {
+	document := loadDocument(t, `
+replicas: 1
+`)
+
+	templatePath := filepath.Join(t.TempDir(), "custom.tpl")
+	require.NoError(t, os.WriteFile(templatePath, []byte(`{{ .Chart.Name }} {{ .Chart.AppVersion }}`), 0600))
+
+	rendered, err := RenderWithOptions(templatePath, document, Options{Chart: &chart.Metadata{Name: "demo", AppVersion: "v1"}})
+	require.NoError(t, err)
+	assert.Equal(t, "demo v1", rendered)
+}
+
+func TestRender_ChartHeaderBadgesEscaped(t *testing.T) {
+	document := loadDocument(t, `
+replicas: 1
+`)
+
+	metadata := &chart.Metadata{Name: "demo", Version: "v1.0.0", AppVersion: "v1.0.0_[rc]*"}
+
+	rendered, err := RenderWithOptions("chart-header", document, Options{Chart: metadata})
+	require.NoError(t, err)
+	assert.Contains(t, rendered, "![AppVersion: v1.0.0\\_\\[rc\\]\\*](")
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/cert-manager/helm-tool/parser"
+)
+
+// defaultDisplay returns how the default of the property is shown: the value
+// of its +docs:defaultDisplay tag, or collapsed if the default has more lines
+// than the collapseLines limit (0 disables the limit), or full otherwise.
+func defaultDisplay(property parser.Property, collapseLines int) (parser.DefaultDisplay, error) {
+	display, err := property.DefaultDisplay()
+	if err != nil {
+		return "", err
+	}
+
+	switch {
+	case display != parser.DefaultDisplayAuto:
+		return display, nil
+	case collapseLines > 0 && strings.Count(property.Default, "\n")+1 > collapseLines:
+		return parser.DefaultDisplayCollapsed, nil
+	default:
+		return parser.DefaultDisplayFull, nil
+	}
+}
+
+// defaultSummary returns a one line summary of a YAML value, eg. "{…12 keys}"
+// for objects, "[…3 items]" for arrays and the first line of other values.
+func defaultSummary(value string) string {
+	var node yaml.Node
+	if err := yaml.Unmarshal([]byte(value), &node); err == nil && len(node.Content) == 1 {
+		switch content := node.Content[0]; content.Kind {
+		case yaml.MappingNode:
+			return "{…" + plural(len(content.Content)/2, "key") + "}"
+		case yaml.SequenceNode:
+			return "[…" + plural(len(content.Content), "item") + "]"
+		}
+	}
+
+	if first, _, multiline := strings.Cut(strings.TrimSpace(value), "\n"); multiline {
+		return first + "…"
+	}
+
+	return strings.TrimSpace(value)
+}
+
+func plural(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("%d %s", count, noun)
+	}
+
+	return fmt.Sprintf("%d %ss", count, noun)
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/helm-tool/parser"
+)
+
+func TestDefaultSummary(t *testing.T) {
+	assert.Equal(t, "{…2 keys}", defaultSummary("a: 1\nb: 2"))
+	assert.Equal(t, "{…1 key}", defaultSummary("a:\n  b: 2"))
+	assert.Equal(t, "{…0 keys}", defaultSummary("{}"))
+	assert.Equal(t, "[…3 items]", defaultSummary("- a\n- b\n- c"))
+	assert.Equal(t, "|-…", defaultSummary("|-\n  line 1\n  line 2"))
+	assert.Equal(t, `"v1"`, defaultSummary(`"v1"`))
+}
+
+const defaultDisplayValues = `
+# +docs:defaultDisplay=hidden
+name: app
+# +docs:property
+# +docs:defaultDisplay=full
+labels:
+  a: "1"
+  b: "2"
+# +docs:property
+annotations:
+  a: "1"
+  b: "2"
+# +docs:defaultDisplay=collapsed
+repository: example.com/app
+`
+
+func TestDefaultDisplay(t *testing.T) {
+	document := loadDocument(t, defaultDisplayValues)
+
+	displays := map[string]parser.DefaultDisplay{}
+	for _, property := range document.Sections[0].Properties {
+		display, err := defaultDisplay(property, 1)
+		require.NoError(t, err)
+		displays[property.Path.String()] = display
+	}
+
+	assert.Equal(t, map[string]parser.DefaultDisplay{
+		"name":        parser.DefaultDisplayHidden,
+		"labels":      parser.DefaultDisplayFull,
+		"annotations": parser.DefaultDisplayCollapsed,
+		"repository":  parser.DefaultDisplayCollapsed,
+	}, displays)
+
+	display, err := defaultDisplay(document.Sections[0].Properties[2], 0)
+	require.NoError(t, err)
+	assert.Equal(t, parser.DefaultDisplayFull, display)
+}
+
+func TestRender_CollapseDefaults(t *testing.T) {
+	document := loadDocument(t, defaultDisplayValues)
+
+	rendered, err := RenderWithOptions("markdown-table", document, Options{CollapseDefaults: 1})
+	require.NoError(t, err)
+	assert.NotContains(t, rendered, "```yaml\napp\n")
+	assert.Contains(t, rendered, "<td>\n\n```yaml\na: \"1\"\nb: \"2\"\n```\n\n</td>")
+	assert.Contains(t, rendered, "<details>\n<summary><code>{…2 keys}</code></summary>\n\n```yaml\na: \"1\"\nb: \"2\"\n```\n\n</details>")
+
+	document = loadDocument(t, "# +docs:defaultDisplay=short\nname: app\n")
+	_, err = Render("markdown-table", document)
+	assert.ErrorContains(t, err, `property "name": invalid docs:defaultDisplay value "short", expected "full", "collapsed" or "hidden"`)
+}
@@ -0,0 +1,301 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"fmt"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/cert-manager/helm-tool/heuristics"
+	"github.com/cert-manager/helm-tool/parser"
+	"github.com/cert-manager/helm-tool/paths"
+)
+
+// exampleNode is an object, array or value of the example values file.
+type exampleNode struct {
+	// name is the key of the node, it is empty for array items.
+	name string
+	// comments are the comment blocks written before the node, foot the
+	// comment blocks written after it.
+	comments []string
+	foot     []string
+	// value is the default of a property, it is nil for the objects and
+	// arrays leading to properties.
+	value    *yaml.Node
+	array    bool
+	children []*exampleNode
+}
+
+// ExampleValues returns a values file containing every property of the
+// document with its default value, preceded by its description and grouped
+// by section. Properties without a default are commented out, with a
+// placeholder of their type, as +docs:property blocks naming their path.
+//
+// The comments keep all tags, so the result can be read back by parser.Load
+// and documents the same properties.
+func ExampleValues(document *parser.Document) (string, error) {
+	root := &exampleNode{}
+
+	// Comment blocks of sections and commented out properties are written
+	// before the next property that has a value, or at the end of the file.
+	// Commented out properties directly following a sibling are written
+	// after that sibling instead, to be indented like it.
+	var pending []string
+	var pendingSection bool
+	var previous *exampleNode
+	var previousPath paths.Path
+	for i, section := range document.Sections {
+		if i > 0 || section.Name != "" {
+			pending = appendBlock(pending, commentLines(section.Description.Segments, ""))
+			pendingSection = true
+		}
+
+		for _, property := range section.Properties {
+			if property.Default == "" {
+				commented, err := commentedProperty(property)
+				if err != nil {
+					return "", err
+				}
+
+				if len(pending) == 0 && previous != nil && previousPath.Parent().Equal(property.Path.Parent()) {
+					previous.foot = append(previous.foot, commented)
+				} else {
+					pending = append(pending, commented)
+					pendingSection = false
+				}
+				continue
+			}
+
+			node, created, err := root.child(property.Path)
+			if err != nil {
+				return "", err
+			}
+
+			// Section comments are written before the first object created
+			// for the property, like in hand-written values files. The last
+			// comment of an object cannot be a commented out property, it
+			// would document the object itself.
+			if pendingSection && created != nil && created != node {
+				created.comments = pending
+				pending = nil
+			}
+
+			var value yaml.Node
+			if err := yaml.Unmarshal([]byte(property.Default), &value); err == nil && len(value.Content) == 1 {
+				node.value = value.Content[0]
+			} else {
+				// Defaults set with +docs:default are not always YAML
+				node.value = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: property.Default}
+			}
+
+			node.comments = appendBlock(pending, commentLines(property.Description.Segments, ""))
+			pending, pendingSection = nil, false
+			previous, previousPath = node, property.Path
+		}
+	}
+
+	var sb strings.Builder
+	if err := root.writeChildren(&sb, ""); err != nil {
+		return "", err
+	}
+	if len(pending) > 0 {
+		sb.WriteString("\n")
+		writeCommentBlocks(&sb, "", pending)
+	}
+
+	return sb.String(), nil
+}
+
+// child returns the node of the path, creating the objects and arrays leading
+// to it. The first object created for the path is also returned.
+func (n *exampleNode) child(path paths.Path) (*exampleNode, *exampleNode, error) {
+	node := n
+	var created *exampleNode
+	for depth, component := range path {
+		idx, isIndex := paths.ArrayIndex(component)
+		if len(node.children) == 0 {
+			node.array = isIndex
+		}
+		if node.value != nil || node.array != isIndex {
+			return nil, nil, fmt.Errorf("property %q: %q is documented with a different type", path, path[:depth])
+		}
+
+		if isIndex {
+			for len(node.children) <= idx {
+				node.children = append(node.children, &exampleNode{value: &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null", Value: "null"}})
+			}
+			node = node.children[idx]
+			node.value = nil
+			continue
+		}
+
+		name, _ := paths.PropertyName(component)
+		var child *exampleNode
+		for _, existing := range node.children {
+			if existing.name == name {
+				child = existing
+				break
+			}
+		}
+
+		if child == nil {
+			child = &exampleNode{name: name}
+			node.children = append(node.children, child)
+			if created == nil {
+				created = child
+			}
+		}
+		node = child
+	}
+
+	return node, created, nil
+}
+
+func (n *exampleNode) writeChildren(sb *strings.Builder, indent string) error {
+	for i, child := range n.children {
+		// Siblings are separated by blank lines, otherwise comments following
+		// a nested object are read back as comments of its parent
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		writeCommentBlocks(sb, indent, child.comments)
+
+		prefix := "-"
+		if !n.array {
+			key, err := toYaml(child.name)
+			if err != nil {
+				return err
+			}
+			prefix = key + ":"
+		}
+
+		if child.value == nil {
+			sb.WriteString(indent + prefix + "\n")
+			if err := child.writeChildren(sb, indent+"  "); err != nil {
+				return err
+			}
+		} else {
+			value, err := toYaml(child.value)
+			if err != nil {
+				return err
+			}
+
+			// Scalars and empty objects or arrays stay on the line of the key
+			if child.value.Kind == yaml.ScalarNode && !strings.Contains(value, "\n") || len(child.value.Content) == 0 && child.value.Kind != yaml.ScalarNode {
+				sb.WriteString(indent + prefix + " " + value + "\n")
+			} else {
+				sb.WriteString(indent + prefix + "\n" + indentLines(indent+"  ", value) + "\n")
+			}
+		}
+
+		// Without a blank line, only the first comment block stays attached
+		// to the node when the file is read back
+		if len(child.foot) > 0 {
+			sb.WriteString("\n")
+			writeCommentBlocks(sb, indent, child.foot)
+		}
+	}
+
+	return nil
+}
+
+// commentedProperty returns the comment block documenting a property without
+// default, with a placeholder value of its type.
+func commentedProperty(property parser.Property) (string, error) {
+	placeholder, err := toYaml(map[string]any{
+		paths.SegmentString(property.Path.Property()): examplePlaceholder(property),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return commentLines(property.Description.Segments, property.Path.String()) + "\n" + indentLines("# ", placeholder), nil
+}
+
+// examplePlaceholder returns a value of the type of the property, matching
+// its format so that the format is still inferred from the example.
+func examplePlaceholder(property parser.Property) any {
+	switch property.Format {
+	case parser.FormatDuration:
+		return "0s"
+	case parser.FormatQuantity:
+		return "1Gi"
+	case parser.FormatURL:
+		return "https://example.com"
+	case parser.FormatEmail:
+		return "admin@example.com"
+	case parser.FormatImage:
+		return "registry.example.com/image:latest"
+	case parser.FormatSemver:
+		return "v1.0.0"
+	}
+
+	switch property.Type {
+	case parser.TypeString, parser.TypeNumber, parser.TypeBool, parser.TypeArray, parser.TypeObject:
+		return zeroValue(property.Type)
+	default:
+		return nil
+	}
+}
+
+// commentLines returns the segments as YAML comment lines. If propertyPath is
+// set, the +docs:property tag names the path, as the comment is not always
+// written next to the siblings of the property.
+func commentLines(segments []heuristics.CommentBlockSegment, propertyPath string) string {
+	var lines []string
+	for _, segment := range segments {
+		text := segment.String()
+		if segment.Type == heuristics.ContentTypeTag && propertyPath != "" && strings.HasPrefix(text, "+"+parser.TagProperty) {
+			text = "+" + parser.TagProperty + "=" + propertyPath
+		}
+
+		lines = append(lines, indentLines("# ", text))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func appendBlock(blocks []string, block string) []string {
+	if block == "" {
+		return blocks
+	}
+
+	return append(blocks, block)
+}
+
+// writeCommentBlocks writes the comment blocks separated by blank lines.
+func writeCommentBlocks(sb *strings.Builder, indent string, blocks []string) {
+	for i, block := range blocks {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(indentLines(indent, block) + "\n")
+	}
+}
+
+// indentLines prefixes every line of the text, without leaving trailing
+// spaces.
+func indentLines(prefix string, text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(prefix+line, " ")
+	}
+
+	return strings.Join(lines, "\n")
+}
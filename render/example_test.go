@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/helm-tool/parser"
+)
+
+// summary lists the documented properties of every section, to compare
+// documents.
+func summary(document *parser.Document) [][]string {
+	var result [][]string
+	for _, section := range document.Sections {
+		lines := []string{section.Name, section.Description.String()}
+		for _, property := range section.Properties {
+			lines = append(lines, property.Path.String()+" "+property.DisplayType()+" "+property.Default+" "+property.Description.String())
+		}
+		result = append(result, lines)
+	}
+
+	return result
+}
+
+func TestExampleValues_RoundTrip(t *testing.T) {
+	document, err := parser.Load("../examples/cert-manager/values.yaml", false)
+	require.NoError(t, err)
+
+	example, err := ExampleValues(document)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "values.example.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(example), 0600))
+
+	loaded, err := parser.Load(path, false)
+	require.NoError(t, err)
+	assert.Equal(t, summary(document), summary(loaded))
+}
+
+func TestExampleValues_CommentedProperties(t *testing.T) {
+	values := `# The replica count
+replicas: 1
+
+tls:
+  # Enable TLS
+  enabled: false
+
+  # The secret containing the certificate
+  # +docs:property
+  # secretName: my-secret
+
+# Extra hosts
+hosts:
+  - example.com
+
+# +docs:section=Proxy
+
+# The proxy to use
+# +docs:property
+# proxy: http://proxy.example.com:3128
+
+# Proxy timeout
+# +docs:property
+# timeout: 30s
+`
+
+	dir := t.TempDir()
+	valuesPath := filepath.Join(dir, "values.yaml")
+	require.NoError(t, os.WriteFile(valuesPath, []byte(values), 0600))
+
+	document, err := parser.Load(valuesPath, false)
+	require.NoError(t, err)
+
+	example, err := ExampleValues(document)
+	require.NoError(t, err)
+	assert.Contains(t, example, "  # +docs:property=tls.secretName\n  # secretName: \"\"\n")
+	assert.Contains(t, example, "# +docs:property=proxy\n# proxy: https://example.com\n")
+
+	examplePath := filepath.Join(dir, "values.example.yaml")
+	require.NoError(t, os.WriteFile(examplePath, []byte(example), 0600))
+
+	loaded, err := parser.Load(examplePath, false)
+	require.NoError(t, err)
+	assert.Equal(t, summary(document), summary(loaded))
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"cmp"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/cert-manager/helm-tool/parser"
+	"github.com/cert-manager/helm-tool/paths"
+)
+
+// SortOrder is the order of the properties within a section.
+type SortOrder string
+
+const (
+	// SortFile keeps the order of the values file.
+	SortFile SortOrder = "file"
+	// SortPath orders the properties alphabetically by path.
+	SortPath SortOrder = "path"
+	// SortType groups the properties by type, ordered by path within a type.
+	SortType SortOrder = "type"
+)
+
+// filterDocument returns a copy of the document with the sections, include
+// and exclude globs and sort order of the options applied. The document is
+// returned as is if no filter is set.
+func filterDocument(document *parser.Document, options Options) (*parser.Document, error) {
+	if len(options.Sections) == 0 && len(options.Include) == 0 && len(options.Exclude) == 0 && (options.Sort == "" || options.Sort == SortFile) {
+		return document, nil
+	}
+
+	document, err := filterSections(document, options.Sections)
+	if err != nil {
+		return nil, err
+	}
+
+	include, err := compileGlobs(options.Include)
+	if err != nil {
+		return nil, err
+	}
+
+	exclude, err := compileGlobs(options.Exclude)
+	if err != nil {
+		return nil, err
+	}
+
+	var compare func(a, b parser.Property) int
+	switch options.Sort {
+	case "", SortFile:
+	case SortPath:
+		compare = func(a, b parser.Property) int {
+			return a.Path.Compare(b.Path)
+		}
+	case SortType:
+		compare = func(a, b parser.Property) int {
+			return cmp.Or(strings.Compare(a.DisplayType(), b.DisplayType()), a.Path.Compare(b.Path))
+		}
+	default:
+		return nil, fmt.Errorf("invalid sort order %q, expected %q, %q or %q", options.Sort, SortFile, SortPath, SortType)
+	}
+
+	filtered := &parser.Document{}
+	for _, section := range document.Sections {
+		var properties []parser.Property
+		for _, property := range section.Properties {
+			if (len(include) == 0 || matchesGlobs(include, property.Path)) && !matchesGlobs(exclude, property.Path) {
+				properties = append(properties, property)
+			}
+		}
+
+		// Sections that only had filtered out properties are dropped
+		if len(properties) == 0 && len(section.Properties) > 0 {
+			continue
+		}
+
+		if compare != nil {
+			slices.SortStableFunc(properties, compare)
+		}
+
+		section.Properties = properties
+		filtered.Sections = append(filtered.Sections, section)
+	}
+
+	return filtered, nil
+}
+
+// compileGlobs compiles path globs, see paths.CompileGlob.
+func compileGlobs(globs []string) ([]*regexp.Regexp, error) {
+	var result []*regexp.Regexp
+	for _, glob := range globs {
+		compiled, err := paths.CompileGlob(glob)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, compiled)
+	}
+
+	return result, nil
+}
+
+// matchesGlobs returns true if a glob matches the path or one of its parents,
+// so that a glob matching an object also matches the properties it contains.
+func matchesGlobs(globs []*regexp.Regexp, path paths.Path) bool {
+	for i := len(path); i > 0; i-- {
+		pathString := path[:i].String()
+		for _, glob := range globs {
+			if glob.MatchString(pathString) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
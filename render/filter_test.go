@@ -0,0 +1,156 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/helm-tool/parser"
+)
+
+const filterValues = `
+# The number of replicas
+replicaCount: 1
+
+image:
+  # The image tag
+  tag: v1
+  # The image repository
+  repository: cert-manager
+
+# +docs:section=Webhook
+
+webhook:
+  # The webhook timeout
+  timeoutSeconds: 30
+  image:
+    # The webhook image tag
+    tag: v1
+  # Extra arguments
+  extraArgs: ["--a", "--b"]
+`
+
+// documentPaths lists the paths of the document per section.
+func documentPaths(document *parser.Document) map[string][]string {
+	result := map[string][]string{}
+	for _, section := range document.Sections {
+		result[section.Name] = []string{}
+		for _, property := range section.Properties {
+			result[section.Name] = append(result[section.Name], property.Path.String())
+		}
+	}
+
+	return result
+}
+
+func TestFilterDocument(t *testing.T) {
+	document := loadDocument(t, filterValues)
+
+	tests := []struct {
+		name     string
+		options  Options
+		expected map[string][]string
+	}{
+		{
+			name:    "sort by path",
+			options: Options{Sort: SortPath},
+			expected: map[string][]string{
+				"":        {"image.repository", "image.tag", "replicaCount"},
+				"Webhook": {"webhook.extraArgs[0]", "webhook.extraArgs[1]", "webhook.image.tag", "webhook.timeoutSeconds"},
+			},
+		},
+		{
+			name:    "sort by type",
+			options: Options{Sort: SortType, Sections: []string{""}},
+			expected: map[string][]string{
+				"": {"replicaCount", "image.repository", "image.tag"},
+			},
+		},
+		{
+			name:    "include object",
+			options: Options{Include: []string{"webhook"}},
+			expected: map[string][]string{
+				"Webhook": {"webhook.timeoutSeconds", "webhook.image.tag", "webhook.extraArgs[0]", "webhook.extraArgs[1]"},
+			},
+		},
+		{
+			name:    "include and exclude globs",
+			options: Options{Include: []string{"*.image", "replica*"}, Exclude: []string{"**.repository", "webhook.extraArgs[*]"}},
+			expected: map[string][]string{
+				"":        {"replicaCount"},
+				"Webhook": {"webhook.image.tag"},
+			},
+		},
+		{
+			name:    "single star does not cross components",
+			options: Options{Include: []string{"*.tag"}},
+			expected: map[string][]string{
+				"": {"image.tag"},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			filtered, err := filterDocument(document, test.options)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, documentPaths(filtered))
+		})
+	}
+
+	// The document itself is not modified
+	assert.Equal(t, "replicaCount", document.Sections[0].Properties[0].Path.String())
+
+	_, err := filterDocument(document, Options{Sort: "size"})
+	assert.ErrorContains(t, err, `invalid sort order "size"`)
+
+	_, err = filterDocument(document, Options{Sections: []string{"Controller"}})
+	assert.ErrorContains(t, err, `unknown section "Controller"`)
+}
+
+func TestRender_Filtered(t *testing.T) {
+	document := loadDocument(t, filterValues)
+
+	rendered, err := RenderWithOptions("markdown-plain", document, Options{Include: []string{"webhook.image"}})
+	require.NoError(t, err)
+	assert.Contains(t, rendered, "#### **webhook.image.tag**")
+	assert.NotContains(t, rendered, "#### **image.tag**")
+	assert.NotContains(t, rendered, "webhook.timeoutSeconds")
+}
+
+func TestInject_FilteredRegions(t *testing.T) {
+	document := loadDocument(t, filterValues)
+
+	path := filepath.Join(t.TempDir(), "README.md")
+	original := "<!-- helm-tool:begin name=general sections=\"\" -->\n<!-- helm-tool:end -->\n" +
+		"<!-- helm-tool:begin name=webhook sections=Webhook -->\n<!-- helm-tool:end -->\n"
+	require.NoError(t, os.WriteFile(path, []byte(original), 0600))
+
+	// The Webhook section is removed by the include glob, its region is empty
+	_, err := InjectWithOptions(path, "markdown-plain", document, testHeader, testFooter, Options{Include: []string{"image"}})
+	require.NoError(t, err)
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(contents), "#### **image.tag**")
+	assert.NotContains(t, string(contents), "webhook.image.tag")
+}
@@ -0,0 +1,355 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"github.com/Masterminds/sprig/v3"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/cert-manager/helm-tool/parser"
+	"github.com/cert-manager/helm-tool/paths"
+)
+
+var (
+	anchorInvalidExp = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+	codeSpanExp      = regexp.MustCompile("`([^`]+)`")
+
+	markdownEscaper = strings.NewReplacer(`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`, `<`, `\<`, `>`, `\>`, `|`, `\|`, `~`, `\~`)
+	// Characters that start a heading, list or quote at the start of a line
+	markdownBlockExp = regexp.MustCompile(`(?m)^[ \t]*(?:[#+=-]|\d+\.)`)
+
+	asciidocEscaper = strings.NewReplacer(`|`, `\|`, `{`, `\{`)
+	rstEscaper      = strings.NewReplacer(`\`, `\\`, `*`, `\*`, "`", "\\`", `|`, `\|`)
+	// Underscores only have a meaning at the end of a word, where they make
+	// the word a reference
+	rstReferenceExp = regexp.MustCompile(`_(\W|$)`)
+
+	// badgeEscaper escapes the separators of shields.io static badges
+	badgeEscaper = strings.NewReplacer("-", "--", "_", "__", " ", "_")
+)
+
+// funcMap returns the functions available in templates rendering the
+// document with the options, linking the documented paths with links.
+func funcMap(document *parser.Document, links pathLinks, options Options) template.FuncMap {
+	funcMap := sprig.HermeticTxtFuncMap()
+
+	funcMap["indentWith"] = func(pad string, v string) string {
+		return pad + strings.ReplaceAll(v, "\n", "\n"+pad)
+	}
+
+	funcMap["anchor"] = anchorID
+	funcMap["markdownEscape"] = markdownEscape
+	funcMap["markdownLinkPaths"] = links.markdown
+	funcMap["badgeURL"] = badgeURL
+	funcMap["setFlag"] = setFlag
+	funcMap["setIndexFlags"] = setIndexFlags
+	funcMap["defaultDisplay"] = func(property parser.Property) (string, error) {
+		display, err := defaultDisplay(property, options.CollapseDefaults)
+		return string(display), err
+	}
+	funcMap["defaultSummary"] = defaultSummary
+	funcMap["toYaml"] = toYaml
+	funcMap["fromYaml"] = fromYaml
+
+	funcMap["pathParent"] = func(v any) (paths.Path, error) {
+		path, err := toPath(v)
+		return path.Parent(), err
+	}
+	funcMap["pathSegments"] = pathSegments
+	funcMap["isDeprecated"] = func(property parser.Property) bool {
+		return property.IsDeprecated()
+	}
+	funcMap["propertiesUnder"] = func(v any) ([]parser.Property, error) {
+		return propertiesUnder(document, v)
+	}
+	funcMap["propertyTree"] = propertyTree
+	funcMap["isDocumented"] = func(v any) (bool, error) {
+		path, err := toPath(v)
+		return links.documented(path.String()), err
+	}
+	funcMap["pathLink"] = func(v any) (string, error) {
+		path, err := toPath(v)
+		return links.href(path.String()), err
+	}
+
+	funcMap["highlightYaml"] = highlightYaml
+	funcMap["htmlText"] = func(text string) htmltemplate.HTML {
+		return htmlText(text, links)
+	}
+
+	funcMap["asciidocEscape"] = asciidocEscape
+	funcMap["asciidocHardBreaks"] = asciidocHardBreaks
+	funcMap["asciidocCodeBlock"] = asciidocCodeBlock
+	funcMap["asciidocLinkPaths"] = links.asciidoc
+	funcMap["asciidocAnchor"] = func(v any) string {
+		return "[[" + anchorID(v) + "]]"
+	}
+
+	funcMap["rstEscape"] = rstEscape
+	funcMap["rstCodeBlock"] = rstCodeBlock
+	funcMap["rstIndent"] = rstIndent
+	funcMap["rstLinkPaths"] = links.rst
+	funcMap["rstAnchor"] = func(v any) string {
+		return ".. _" + anchorID(v) + ":"
+	}
+	funcMap["rstTitle"] = rstTitle
+
+	return funcMap
+}
+
+// toPath converts a path or path string to a path.
+func toPath(v any) (paths.Path, error) {
+	switch v := v.(type) {
+	case paths.Path:
+		return v, nil
+	case string:
+		return paths.Parse(v)
+	case fmt.Stringer:
+		return paths.Parse(v.String())
+	default:
+		return nil, fmt.Errorf("expected a path, got %T", v)
+	}
+}
+
+// pathSegments returns the components of the path, property names as strings
+// and array indices as ints.
+func pathSegments(v any) ([]any, error) {
+	path, err := toPath(v)
+	if err != nil {
+		return nil, err
+	}
+
+	segments := []any{}
+	for _, component := range path {
+		if idx, ok := paths.ArrayIndex(component); ok {
+			segments = append(segments, idx)
+		} else {
+			name, _ := paths.PropertyName(component)
+			segments = append(segments, name)
+		}
+	}
+
+	return segments, nil
+}
+
+// propertiesUnder returns the documented properties at or below the path, in
+// the order of the document.
+func propertiesUnder(document *parser.Document, v any) ([]parser.Property, error) {
+	path, err := toPath(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var properties []parser.Property
+	for _, section := range document.Sections {
+		for _, property := range section.Properties {
+			if path.IsSubPathOf(property.Path) {
+				properties = append(properties, property)
+			}
+		}
+	}
+
+	return properties, nil
+}
+
+// markdownEscape escapes the characters that have a meaning in markdown, so
+// the text is rendered as is.
+func markdownEscape(text string) string {
+	text = markdownEscaper.Replace(text)
+	return markdownBlockExp.ReplaceAllStringFunc(text, func(match string) string {
+		// Escape the dot of "1." and the character of "#", "-", "+" or "="
+		if strings.HasSuffix(match, ".") {
+			return match[:len(match)-1] + `\.`
+		}
+		return match[:len(match)-1] + `\` + match[len(match)-1:]
+	})
+}
+
+// badgeURL returns the URL of a shields.io static badge.
+func badgeURL(label, message, color string) string {
+	escape := func(text string) string {
+		return url.PathEscape(badgeEscaper.Replace(text))
+	}
+
+	return "https://img.shields.io/badge/" + escape(label) + "-" + escape(message) + "-" + escape(color) + "?style=flat-square"
+}
+
+func toYaml(v any) (string, error) {
+	var sb strings.Builder
+	encoder := yaml.NewEncoder(&sb)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(v); err != nil {
+		return "", err
+	}
+	if err := encoder.Close(); err != nil {
+		return "", err
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n"), nil
+}
+
+func fromYaml(text string) (any, error) {
+	var v any
+	if err := yaml.Unmarshal([]byte(text), &v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// anchorID returns an identifier for v (usually a property path) that is
+// valid as an anchor in markdown, AsciiDoc and reStructuredText. The
+// components of a path are separated by dashes, and the characters of
+// property names other than letters and digits are escaped (underscores as __,
+// the others as _<hex>), so two different paths never get the same anchor.
+// Property names made of digits only are escaped too, to tell them from array
+// indices.
+func anchorID(v any) string {
+	path, err := toPath(v)
+	if err != nil || len(path) == 0 {
+		return slug(fmt.Sprint(v))
+	}
+
+	var sb strings.Builder
+	for i, component := range path {
+		if i > 0 {
+			sb.WriteByte('-')
+		}
+
+		if idx, ok := paths.ArrayIndex(component); ok {
+			if i == 0 {
+				sb.WriteString("_i")
+			}
+			sb.WriteString(strconv.Itoa(idx))
+			continue
+		}
+
+		name, _ := paths.PropertyName(component)
+		if name == "" {
+			sb.WriteByte('_')
+			continue
+		}
+
+		allDigits := strings.Trim(name, "0123456789") == ""
+		for j := 0; j < len(name); j++ {
+			c := name[j]
+			switch {
+			case c == '_':
+				sb.WriteString("__")
+			case (j == 0 && allDigits) || !isAlphanumeric(c):
+				fmt.Fprintf(&sb, "_%02x", c)
+			default:
+				sb.WriteByte(c)
+			}
+		}
+	}
+
+	return sb.String()
+}
+
+// slug returns an identifier for the text that is valid as an anchor or
+// filename, runs of other characters than letters, digits, dashes and
+// underscores are replaced by a dash.
+func slug(text string) string {
+	id := strings.Trim(anchorInvalidExp.ReplaceAllString(text, "-"), "-")
+	if id == "" || (id[0] >= '0' && id[0] <= '9') {
+		id = "_" + id
+	}
+
+	return id
+}
+
+func isAlphanumeric(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// asciidocEscape escapes the characters that would otherwise end an AsciiDoc
+// table cell or start an attribute reference.
+func asciidocEscape(text string) string {
+	return asciidocEscaper.Replace(text)
+}
+
+// asciidocHardBreaks keeps the line breaks within the paragraphs of the text,
+// AsciiDoc joins the lines of a paragraph otherwise.
+func asciidocHardBreaks(text string) string {
+	lines := strings.Split(text, "\n")
+	for i := range len(lines) - 1 {
+		if strings.TrimSpace(lines[i]) != "" && strings.TrimSpace(lines[i+1]) != "" {
+			lines[i] += " +"
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func asciidocCodeBlock(language string, code string) string {
+	return "[source," + language + "]\n----\n" + code + "\n----"
+}
+
+// rstEscape escapes reStructuredText inline markup. Code spans written
+// markdown style (`code`) are kept, as inline literals.
+func rstEscape(text string) string {
+	var sb strings.Builder
+
+	last := 0
+	for _, match := range codeSpanExp.FindAllStringSubmatchIndex(text, -1) {
+		sb.WriteString(rstEscapeText(text[last:match[0]]))
+		sb.WriteString("``" + text[match[2]:match[3]] + "``")
+		last = match[1]
+	}
+	sb.WriteString(rstEscapeText(text[last:]))
+
+	return sb.String()
+}
+
+func rstEscapeText(text string) string {
+	return rstReferenceExp.ReplaceAllString(rstEscaper.Replace(text), `\_$1`)
+}
+
+func rstCodeBlock(language string, code string) string {
+	return ".. code-block:: " + language + "\n\n" + rstIndent("   ", code)
+}
+
+// rstIndent indents the non-empty lines of the text, to nest it in a
+// directive or list item.
+func rstIndent(pad string, text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) != "" {
+			lines[i] = pad + line
+		} else {
+			lines[i] = ""
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// rstTitle returns the title underlined with the character, which determines
+// the level of the title.
+func rstTitle(underline string, title string) string {
+	return title + "\n" + strings.Repeat(underline, utf8.RuneCountInString(title))
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/helm-tool/paths"
+)
+
+func TestAnchorID(t *testing.T) {
+	path := paths.Path{}.WithProperty("webhook").WithProperty("extraArgs").WithIndex(0)
+	assert.Equal(t, "webhook-extraArgs-0", anchorID(path))
+	assert.Equal(t, "_30-foo", anchorID("0.foo"))
+	assert.Equal(t, "http__proxy", anchorID("http_proxy"))
+
+	// Different paths never share an anchor
+	anchors := map[string]string{}
+	for _, path := range []string{"a.b", "a-b", "a_b", "a_2db", `foo["a.b"]`, "foo.a.b", "foo[0]", `foo["0"]`, "foo.a b", "foo.a_20b"} {
+		anchor := anchorID(path)
+		assert.Regexp(t, "^[A-Za-z_][A-Za-z0-9_-]*$", anchor)
+		assert.NotContains(t, anchors, anchor, "%s and %s", anchors[anchor], path)
+		anchors[anchor] = path
+	}
+}
+
+func TestAsciidocEscape(t *testing.T) {
+	assert.Equal(t, `a \| b \{attr}`, asciidocEscape("a | b {attr}"))
+	assert.Equal(t, "first +\nsecond\n\nthird", asciidocHardBreaks("first\nsecond\n\nthird"))
+}
+
+func TestRstEscape(t *testing.T) {
+	assert.Equal(t, "set ``foo_bar`` to \\*all\\* or see link\\_ and snake_case", rstEscape("set `foo_bar` to *all* or see link_ and snake_case"))
+	assert.Equal(t, ".. code-block:: yaml\n\n   a:\n\n     b: c", rstCodeBlock("yaml", "a:\n\n  b: c"))
+	assert.Equal(t, "Title\n-----", rstTitle("-", "Title"))
+}
+
+func TestMarkdownEscape(t *testing.T) {
+	assert.Equal(t, "a\\_b \\*c\\* \\`d\\` \\<e\\> \\[f\\](g)\n\\# h\n  \\- i\n1\\. j", markdownEscape("a_b *c* `d` <e> [f](g)\n# h\n  - i\n1. j"))
+}
+
+func TestPathHelpers(t *testing.T) {
+	segments, err := pathSegments(`podLabels["app.kubernetes.io/name"]`)
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"podLabels", "app.kubernetes.io/name"}, segments)
+
+	segments, err = pathSegments(paths.Path{}.WithProperty("extraArgs").WithIndex(1))
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"extraArgs", 1}, segments)
+
+	_, err = pathSegments(1)
+	assert.ErrorContains(t, err, "expected a path, got int")
+}
+
+func TestRenderFuncs(t *testing.T) {
+	document := loadDocument(t, `
+image:
+  repository: example.com/app
+  # +docs:deprecated
+  tag: v1
+replicas: 1
+`)
+
+	dir := t.TempDir()
+	templatePath := filepath.Join(dir, "custom.tpl")
+	require.NoError(t, os.WriteFile(templatePath, []byte(`
+{{- range propertiesUnder "image" -}}
+{{ .Path }} parent={{ pathParent .Path }} deprecated={{ isDeprecated . }} anchor={{ anchor .Path }}
+{{ end -}}
+{{ $value := fromYaml "a: [1, 2]" }}{{ toYaml $value }}`), 0600))
+
+	rendered, err := Render(templatePath, document)
+	require.NoError(t, err)
+	assert.Equal(t, `image.repository parent=image deprecated=false anchor=image-repository
+image.tag parent=image deprecated=true anchor=image-tag
+a:
+  - 1
+  - 2`, rendered)
+}
@@ -0,0 +1,206 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	htmltemplate "html/template"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/cert-manager/helm-tool/parser"
+	"github.com/cert-manager/helm-tool/paths"
+)
+
+var (
+	yamlKeyExp    = regexp.MustCompile(`^(\s*(?:-\s+)*)([^\s#'"{\[][^#]*?|"[^"]*"|'[^']*')(:)(\s|$)`)
+	yamlItemExp   = regexp.MustCompile(`^(\s*(?:-\s+)+)`)
+	yamlNumberExp = regexp.MustCompile(`^[-+]?(\.[0-9]+|[0-9]+(\.[0-9]*)?)([eE][-+]?[0-9]+)?$`)
+	urlExp        = regexp.MustCompile(`https?://[^\s<>"')\]]+`)
+)
+
+// isHTMLTemplate reports whether the template produces HTML, these templates
+// are executed with html/template so that all values are escaped.
+func isHTMLTemplate(templateName string) bool {
+	switch strings.ToLower(filepath.Ext(templateName)) {
+	case ".html", ".htm", ".gohtml":
+		return true
+	}
+
+	return filepath.Base(templateName) == "html"
+}
+
+// TreeNode is a level of the values tree, following the nesting of objects
+// and arrays.
+type TreeNode struct {
+	// Name is the last component of the path, eg. "tag" or "[0]".
+	Name string
+	Path paths.Path
+	// Property is the documented property at this level, it is nil for
+	// levels that only group documented properties.
+	Property *parser.Property
+	Children []*TreeNode
+}
+
+// propertyTree nests the properties following their paths.
+func propertyTree(properties []parser.Property) []*TreeNode {
+	root := &TreeNode{}
+	for i := range properties {
+		node := root
+		for depth := range properties[i].Path {
+			path := properties[i].Path[:depth+1]
+
+			var child *TreeNode
+			for _, existing := range node.Children {
+				if existing.Path.Equal(path) {
+					child = existing
+					break
+				}
+			}
+
+			if child == nil {
+				child = &TreeNode{
+					Name: paths.SegmentString(path.Property()),
+					Path: path,
+				}
+				node.Children = append(node.Children, child)
+			}
+			node = child
+		}
+		node.Property = &properties[i]
+	}
+
+	return root.Children
+}
+
+// highlightYaml returns the YAML as HTML, with spans marking keys, scalars and
+// comments.
+func highlightYaml(source string) htmltemplate.HTML {
+	var sb strings.Builder
+	for i, line := range strings.Split(source, "\n") {
+		if i > 0 {
+			sb.WriteByte('\n')
+		}
+
+		code, comment := splitYamlComment(line)
+
+		if match := yamlKeyExp.FindStringSubmatchIndex(code); match != nil {
+			sb.WriteString(htmltemplate.HTMLEscapeString(code[:match[3]]))
+			writeSpan(&sb, "key", code[match[4]:match[5]])
+			sb.WriteString(":")
+			code = code[match[7]:]
+			sb.WriteString(htmltemplate.HTMLEscapeString(code[:len(code)-len(strings.TrimLeft(code, " \t"))]))
+			code = strings.TrimLeft(code, " \t")
+		} else if match := yamlItemExp.FindString(code); match != "" {
+			sb.WriteString(htmltemplate.HTMLEscapeString(match))
+			code = code[len(match):]
+		}
+
+		trimmed := strings.TrimRight(code, " \t")
+		if trimmed != "" {
+			writeSpan(&sb, scalarClass(trimmed), trimmed)
+		}
+		sb.WriteString(htmltemplate.HTMLEscapeString(code[len(trimmed):]))
+
+		if comment != "" {
+			writeSpan(&sb, "comment", comment)
+		}
+	}
+
+	return htmltemplate.HTML(sb.String())
+}
+
+// splitYamlComment splits the line before the comment, ignoring '#' inside
+// quoted strings and '#' that are not preceded by whitespace.
+func splitYamlComment(line string) (string, string) {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i], line[i:]
+		}
+	}
+
+	return line, ""
+}
+
+func scalarClass(value string) string {
+	switch {
+	case strings.HasPrefix(value, `"`) || strings.HasPrefix(value, `'`):
+		return "string"
+	case yamlNumberExp.MatchString(value):
+		return "number"
+	}
+
+	switch value {
+	case "true", "false", "null", "~":
+		return "literal"
+	case "|", "|-", ">", ">-", "[]", "{}":
+		return "punctuation"
+	}
+
+	return "string"
+}
+
+func writeSpan(sb *strings.Builder, class string, text string) {
+	sb.WriteString(`<span class="` + class + `">`)
+	sb.WriteString(htmltemplate.HTMLEscapeString(text))
+	sb.WriteString(`</span>`)
+}
+
+// htmlText returns the text as HTML, with markdown code spans as code
+// elements and URLs as links. Code spans naming a documented property link to
+// the property.
+func htmlText(text string, links pathLinks) htmltemplate.HTML {
+	var sb strings.Builder
+
+	last := 0
+	for _, match := range codeSpanExp.FindAllStringSubmatchIndex(text, -1) {
+		sb.WriteString(linkURLs(text[last:match[0]]))
+		code := text[match[2]:match[3]]
+		if links.documented(code) {
+			sb.WriteString(`<a href="#` + anchorID(code) + `"><code>` + htmltemplate.HTMLEscapeString(code) + "</code></a>")
+		} else {
+			sb.WriteString("<code>" + htmltemplate.HTMLEscapeString(code) + "</code>")
+		}
+		last = match[1]
+	}
+	sb.WriteString(linkURLs(text[last:]))
+
+	return htmltemplate.HTML(sb.String())
+}
+
+func linkURLs(text string) string {
+	var sb strings.Builder
+
+	last := 0
+	for _, match := range urlExp.FindAllStringIndex(text, -1) {
+		url := strings.TrimRight(text[match[0]:match[1]], ".,;:")
+		sb.WriteString(htmltemplate.HTMLEscapeString(text[last:match[0]]))
+		sb.WriteString(`<a href="` + htmltemplate.HTMLEscapeString(url) + `">` + htmltemplate.HTMLEscapeString(url) + `</a>`)
+		last = match[0] + len(url)
+	}
+	sb.WriteString(htmltemplate.HTMLEscapeString(text[last:]))
+
+	return sb.String()
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPropertyTree(t *testing.T) {
+	document := loadDocument(t, `
+image:
+  repository: example.com/app
+  tag: v1
+replicas: 1
+`)
+
+	tree := propertyTree(document.Sections[0].Properties)
+	require.Len(t, tree, 2)
+
+	assert.Equal(t, "image", tree[0].Name)
+	assert.Nil(t, tree[0].Property)
+	require.Len(t, tree[0].Children, 2)
+	assert.Equal(t, "image.tag", tree[0].Children[1].Path.String())
+	assert.Equal(t, "v1", tree[0].Children[1].Property.Default)
+
+	assert.Equal(t, "replicas", tree[1].Name)
+	assert.Empty(t, tree[1].Children)
+}
+
+func TestHighlightYaml(t *testing.T) {
+	assert.Equal(t,
+		`<span class="key">name</span>: <span class="string">&#34;a # b&#34;</span> <span class="comment"># comment</span>`+"\n"+
+			`- <span class="key">port</span>: <span class="number">8080</span>`+"\n"+
+			`  <span class="key">enabled</span>: <span class="literal">true</span>`,
+		string(highlightYaml("name: \"a # b\" # comment\n- port: 8080\n  enabled: true")),
+	)
+}
+
+func TestHTMLText(t *testing.T) {
+	assert.Equal(t,
+		`Set <code>a &lt;b&gt;</code>, see <a href="https://example.com/docs">https://example.com/docs</a>.`,
+		string(htmlText("Set `a <b>`, see https://example.com/docs.", nil)),
+	)
+	assert.Equal(t,
+		`Requires <a href="#image-tag"><code>image.tag</code></a>, not <code>image</code>.`,
+		string(htmlText("Requires `image.tag`, not `image`.", pathLinks{"image.tag": ""})),
+	)
+}
+
+func TestSetFlag(t *testing.T) {
+	document := loadDocument(t, `
+replicas: 1
+name: "a,b"
+podLabels:
+  app.kubernetes.io/name: app
+extraArgs: ["--v=2"]
+annotations: {}
+# +docs:property
+# +docs:type=bool
+# enabled: ""
+`)
+
+	flags := map[string]string{}
+	for _, property := range document.Sections[0].Properties {
+		flags[property.Path.String()] = setFlag(property)
+	}
+
+	assert.Equal(t, map[string]string{
+		"replicas":                            `--set replicas=1`,
+		"name":                                `--set-string 'name=a\,b'`,
+		`podLabels["app.kubernetes.io/name"]`: `--set-string 'podLabels.app\.kubernetes\.io/name=app'`,
+		"extraArgs[0]":                        `--set-string 'extraArgs[0]=--v=2'`,
+		"annotations":                         `--set-json 'annotations={}'`,
+		"enabled":                             `--set enabled=false`,
+	}, flags)
+}
+
+func TestRenderHTML(t *testing.T) {
+	document := loadDocument(t, "# Do not <script>alert(1)</script>\nreplicas: 1\n")
+
+	rendered, err := Render("html", document)
+	require.NoError(t, err)
+
+	assert.Contains(t, rendered, `id="replicas"`)
+	assert.Contains(t, rendered, "Do not &lt;script&gt;alert(1)&lt;/script&gt;")
+	assert.NotContains(t, rendered, "<script>alert(1)")
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/cert-manager/helm-tool/parser"
+)
+
+// rstLiteralExp matches the inline literals produced by rstEscape.
+var rstLiteralExp = regexp.MustCompile("``([^`]+)``")
+
+// pathLinks links code spans that exactly match the path of a documented
+// property to the anchor of that property. It maps the paths to the file the
+// property is documented in, empty for the rendered file. Only the properties
+// of the rendered documentation are linked, so the anchors always exist.
+type pathLinks map[string]string
+
+func newPathLinks(document *parser.Document) pathLinks {
+	links := pathLinks{}
+	for _, section := range document.Sections {
+		for _, property := range section.Properties {
+			links[property.Path.String()] = ""
+		}
+	}
+
+	return links
+}
+
+// documented reports whether the path is the path of a documented property.
+func (l pathLinks) documented(path string) bool {
+	_, ok := l[path]
+	return ok
+}
+
+// href returns the link target of the documented property at path.
+func (l pathLinks) href(path string) string {
+	return l[path] + "#" + anchorID(path)
+}
+
+// replace calls link for every match of exp whose first group is a documented
+// path, and replaces the match with the result.
+func (l pathLinks) replace(text string, exp *regexp.Regexp, link func(path, id string) string) string {
+	var sb strings.Builder
+
+	last := 0
+	for _, match := range exp.FindAllStringSubmatchIndex(text, -1) {
+		path := text[match[2]:match[3]]
+		if !l.documented(path) {
+			continue
+		}
+
+		sb.WriteString(text[last:match[0]])
+		sb.WriteString(link(path, anchorID(path)))
+		last = match[1]
+	}
+	sb.WriteString(text[last:])
+
+	return sb.String()
+}
+
+func (l pathLinks) markdown(text string) string {
+	return l.replace(text, codeSpanExp, func(path, id string) string {
+		return "[`" + path + "`](" + l.href(path) + ")"
+	})
+}
+
+func (l pathLinks) asciidoc(text string) string {
+	return l.replace(text, codeSpanExp, func(path, id string) string {
+		return "<<" + id + ",`+" + path + "+`>>"
+	})
+}
+
+// rst links the inline literals of text escaped with rstEscape. Inline markup
+// cannot be nested in reStructuredText, so the links are not literals.
+func (l pathLinks) rst(text string) string {
+	return l.replace(text, rstLiteralExp, func(path, id string) string {
+		return "`" + path + " <" + id + "_>`_"
+	})
+}
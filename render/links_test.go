@@ -0,0 +1,79 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathLinks(t *testing.T) {
+	links := pathLinks{"webhook.timeoutSeconds": ""}
+	text := "Set `webhook.timeoutSeconds`, not `webhook` or webhook.timeoutSeconds."
+
+	assert.Equal(t, "Set [`webhook.timeoutSeconds`](#webhook-timeoutSeconds), not `webhook` or webhook.timeoutSeconds.", links.markdown(text))
+	assert.Equal(t, "Set <<webhook-timeoutSeconds,`+webhook.timeoutSeconds+`>>, not `webhook` or webhook.timeoutSeconds.", links.asciidoc(text))
+	assert.Equal(t, "Set `webhook.timeoutSeconds <webhook-timeoutSeconds_>`_, not ``webhook`` or webhook.timeoutSeconds.", links.rst(rstEscape(text)))
+
+	// Properties documented in another file link to that file
+	links["webhook.timeoutSeconds"] = "webhook.md"
+	assert.Equal(t, "Set [`webhook.timeoutSeconds`](webhook.md#webhook-timeoutSeconds), not `webhook` or webhook.timeoutSeconds.", links.markdown(text))
+}
+
+const crossReferenceValues = `
+# +docs:section=Webhook
+
+webhook:
+  # The timeout of the webhook, see also ` + "`webhook.url`" + `.
+  # +docs:see=webhook.url
+  # +docs:see=webhook.missing
+  timeoutSeconds: 30
+  # The URL of the webhook.
+  url: ""
+`
+
+func TestRender_TOC(t *testing.T) {
+	document := loadDocument(t, crossReferenceValues)
+
+	for _, templateName := range []string{"markdown-plain", "markdown-table", "markdown-table-vertical", "asciidoc-table", "rst-table", "html"} {
+		t.Run(templateName, func(t *testing.T) {
+			rendered, err := Render(templateName, document)
+			require.NoError(t, err)
+			assert.NotContains(t, rendered, "Contents")
+			assert.NotContains(t, rendered, "(#section-Webhook)")
+
+			rendered, err = RenderWithOptions(templateName, document, Options{TOC: true})
+			require.NoError(t, err)
+			assert.Contains(t, rendered, "section-Webhook")
+			assert.Contains(t, rendered, "webhook-timeoutSeconds")
+			assert.Contains(t, rendered, "webhook.missing")
+		})
+	}
+
+	rendered, err := RenderWithOptions("markdown-plain", document, Options{TOC: true})
+	require.NoError(t, err)
+	assert.Contains(t, rendered, "- [Webhook](#section-Webhook)\n"+
+		"  - [`webhook.timeoutSeconds`](#webhook-timeoutSeconds)\n"+
+		"  - [`webhook.url`](#webhook-url)\n")
+	assert.Contains(t, rendered, "<a id=\"section-Webhook\"></a>\n### Webhook\n")
+	assert.Contains(t, rendered, "<a id=\"webhook-url\"></a>\n#### **webhook.url**")
+	assert.Contains(t, rendered, "The timeout of the webhook, see also [`webhook.url`](#webhook-url).")
+	assert.Contains(t, rendered, "See also [`webhook.url`](#webhook-url).")
+	assert.Contains(t, rendered, "See also `webhook.missing`.")
+}
@@ -0,0 +1,268 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/cert-manager/helm-tool/parser"
+)
+
+var attributeExp = regexp.MustCompile(`^([A-Za-z]+)=("[^"]*"|\S+)`)
+
+// markerSyntax is the way markers are written in a type of file, as comments
+// of that file format.
+type markerSyntax struct {
+	// exp matches a marker, capturing whether it is a begin or end marker
+	// and its attributes.
+	exp *regexp.Regexp
+	// separator surrounds the rendered content of a region.
+	separator string
+	// fence matches the lines delimiting code blocks, capturing the
+	// delimiter and the text following it. Markers in code blocks are
+	// examples, eg. in a README documenting the markers. It is nil for
+	// formats whose markers cannot appear in code blocks.
+	fence *regexp.Regexp
+}
+
+var (
+	htmlMarkers = markerSyntax{
+		exp:       regexp.MustCompile(`<!--\s*helm-tool:(begin|end)\b(.*?)-->`),
+		separator: "\n",
+		fence:     regexp.MustCompile("^ {0,3}(`{3,}|~{3,})(.*)$"),
+	}
+	asciidocMarkers = markerSyntax{
+		exp:       regexp.MustCompile(`(?m)^//[ \t]*helm-tool:(begin|end)\b(.*)$`),
+		separator: "\n",
+		fence:     regexp.MustCompile(`^(-{4,}|\.{4,})([ \t]*)$`),
+	}
+	// reStructuredText comments must be followed by a blank line, literal
+	// blocks are indented so they cannot contain markers
+	rstMarkers = markerSyntax{
+		exp:       regexp.MustCompile(`(?m)^\.\.[ \t]+helm-tool:(begin|end)\b(.*)$`),
+		separator: "\n\n",
+	}
+)
+
+// markerSyntaxFor returns the marker syntax for the file, based on its
+// extension. Markdown and HTML comments are used for unknown extensions.
+func markerSyntaxFor(path string) markerSyntax {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".adoc", ".asciidoc", ".asc":
+		return asciidocMarkers
+	case ".rst", ".rest":
+		return rstMarkers
+	default:
+		return htmlMarkers
+	}
+}
+
+// region is a part of a file that is replaced with rendered documentation.
+type region struct {
+	// name identifies the region in error messages, it is empty for the
+	// region found using the header and footer search.
+	name string
+	// template is the template used to render the region, the default
+	// template is used if empty.
+	template string
+	// sections limits the rendered sections to the ones listed, all sections
+	// are rendered if empty.
+	sections []string
+
+	// start and end are the offsets of the replaced content in the file.
+	start, end int
+}
+
+// findMarkerRegions returns the regions between pairs of
+// <!-- helm-tool:begin ... --> and <!-- helm-tool:end --> markers, in the
+// order in which they appear in the file. The begin marker configures the
+// region with the name, template and sections attributes, for example:
+//
+//	<!-- helm-tool:begin name=properties template=markdown-table sections="Global,Controller" -->
+//	<!-- helm-tool:end -->
+//
+// In AsciiDoc and reStructuredText files the markers are written as line
+// comments instead, eg. "// helm-tool:begin name=properties" and
+// ".. helm-tool:begin name=properties".
+func findMarkerRegions(contents []byte, syntax markerSyntax) ([]region, error) {
+	var regions []region
+	var open *region
+
+	codeBlocks := syntax.codeBlocks(contents)
+	for _, match := range syntax.exp.FindAllSubmatchIndex(contents, -1) {
+		if slices.ContainsFunc(codeBlocks, func(block [2]int) bool {
+			return block[0] <= match[0] && match[0] < block[1]
+		}) {
+			continue
+		}
+
+		line := lineOf(contents, match[0])
+
+		if string(contents[match[2]:match[3]]) == "end" {
+			if open == nil {
+				return nil, fmt.Errorf("line %d: end marker without a begin marker", line)
+			}
+			if attributes := strings.TrimSpace(string(contents[match[4]:match[5]])); attributes != "" {
+				return nil, fmt.Errorf("line %d: end marker cannot have attributes, found %q", line, attributes)
+			}
+
+			open.end = match[0]
+			regions = append(regions, *open)
+			open = nil
+			continue
+		}
+
+		if open != nil {
+			return nil, fmt.Errorf("line %d: begin marker inside region %q, which is missing its end marker", line, open.name)
+		}
+
+		newRegion, err := parseBeginMarker(string(contents[match[4]:match[5]]))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		for _, existing := range regions {
+			if existing.name == newRegion.name {
+				return nil, fmt.Errorf("line %d: duplicate region %q", line, newRegion.name)
+			}
+		}
+
+		newRegion.start = match[1]
+		open = &newRegion
+	}
+
+	if open != nil {
+		return nil, fmt.Errorf("region %q is missing its end marker", open.name)
+	}
+
+	return regions, nil
+}
+
+// codeBlocks returns the start and end offsets of the fenced code blocks of
+// the contents. A block is closed by a fence of the same character that is at
+// least as long as the opening fence, an unclosed block runs to the end of the
+// contents.
+func (s markerSyntax) codeBlocks(contents []byte) [][2]int {
+	if s.fence == nil {
+		return nil
+	}
+
+	var blocks [][2]int
+	var opening string
+	start, offset := -1, 0
+	for _, line := range bytes.SplitAfter(contents, []byte("\n")) {
+		match := s.fence.FindStringSubmatch(strings.TrimRight(string(line), "\r\n"))
+		switch {
+		case match == nil:
+		case start < 0:
+			opening, start = match[1], offset
+		case match[1][0] == opening[0] && len(match[1]) >= len(opening) && strings.TrimSpace(match[2]) == "":
+			blocks = append(blocks, [2]int{start, offset + len(line)})
+			start = -1
+		}
+		offset += len(line)
+	}
+
+	if start >= 0 {
+		blocks = append(blocks, [2]int{start, len(contents)})
+	}
+
+	return blocks
+}
+
+func parseBeginMarker(attributes string) (region, error) {
+	var result region
+
+	attributes = strings.TrimSpace(attributes)
+	for attributes != "" {
+		match := attributeExp.FindStringSubmatch(attributes)
+		if match == nil {
+			return region{}, fmt.Errorf("invalid begin marker attributes %q, expected key=value pairs", attributes)
+		}
+		attributes = strings.TrimSpace(attributes[len(match[0]):])
+
+		key, value := match[1], strings.Trim(match[2], `"`)
+		switch key {
+		case "name":
+			result.name = value
+		case "template":
+			result.template = value
+		case "sections":
+			for _, section := range strings.Split(value, ",") {
+				result.sections = append(result.sections, strings.TrimSpace(section))
+			}
+		default:
+			return region{}, fmt.Errorf("unknown begin marker attribute %q", key)
+		}
+	}
+
+	if result.name == "" {
+		return region{}, fmt.Errorf("begin marker is missing the name attribute")
+	}
+
+	return result, nil
+}
+
+// filterSections returns a copy of the document that only contains the
+// listed sections, in the order of the document.
+func filterSections(document *parser.Document, sections []string) (*parser.Document, error) {
+	if err := checkSections(document, sections); err != nil {
+		return nil, err
+	}
+
+	return selectSections(document, sections), nil
+}
+
+// checkSections returns an error if one of the sections is not a section of
+// the document.
+func checkSections(document *parser.Document, sections []string) error {
+	for _, name := range sections {
+		if !slices.ContainsFunc(document.Sections, func(section parser.Section) bool {
+			return section.Name == name
+		}) {
+			return fmt.Errorf("unknown section %q", name)
+		}
+	}
+
+	return nil
+}
+
+// selectSections returns a copy of the document that only contains the listed
+// sections that it has, or the document itself if no section is listed.
+func selectSections(document *parser.Document, sections []string) *parser.Document {
+	if len(sections) == 0 {
+		return document
+	}
+
+	selected := &parser.Document{}
+	for _, section := range document.Sections {
+		if slices.Contains(sections, section.Name) {
+			selected.Sections = append(selected.Sections, section)
+		}
+	}
+
+	return selected
+}
+
+func lineOf(contents []byte, offset int) int {
+	return bytes.Count(contents[:offset], []byte("\n")) + 1
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindMarkerRegions(t *testing.T) {
+	contents := `# Chart
+
+<!-- helm-tool:begin name=properties template=markdown-table sections="Global, Controller" -->
+old content
+<!-- helm-tool:end -->
+
+<!-- helm-tool:begin name=quickstart -->
+<!-- helm-tool:end -->
+`
+
+	regions, err := findMarkerRegions([]byte(contents), htmlMarkers)
+	require.NoError(t, err)
+	require.Len(t, regions, 2)
+
+	assert.Equal(t, "properties", regions[0].name)
+	assert.Equal(t, "markdown-table", regions[0].template)
+	assert.Equal(t, []string{"Global", "Controller"}, regions[0].sections)
+	assert.Equal(t, "\nold content\n", contents[regions[0].start:regions[0].end])
+
+	assert.Equal(t, "quickstart", regions[1].name)
+	assert.Empty(t, regions[1].template)
+	assert.Equal(t, "\n", contents[regions[1].start:regions[1].end])
+}
+
+func TestFindMarkerRegions_Errors(t *testing.T) {
+	tests := map[string]struct {
+		contents string
+		err      string
+	}{
+		"missing end": {
+			contents: "<!-- helm-tool:begin name=a -->\n",
+			err:      `region "a" is missing its end marker`,
+		},
+		"missing begin": {
+			contents: "text\n<!-- helm-tool:end -->\n",
+			err:      "line 2: end marker without a begin marker",
+		},
+		"nested": {
+			contents: "<!-- helm-tool:begin name=a -->\n<!-- helm-tool:begin name=b -->\n",
+			err:      `line 2: begin marker inside region "a"`,
+		},
+		"duplicate": {
+			contents: "<!-- helm-tool:begin name=a --><!-- helm-tool:end -->\n<!-- helm-tool:begin name=a --><!-- helm-tool:end -->\n",
+			err:      `line 2: duplicate region "a"`,
+		},
+		"missing name": {
+			contents: "<!-- helm-tool:begin template=markdown-table -->\n<!-- helm-tool:end -->\n",
+			err:      "missing the name attribute",
+		},
+		"unknown attribute": {
+			contents: "<!-- helm-tool:begin name=a color=red -->\n<!-- helm-tool:end -->\n",
+			err:      `unknown begin marker attribute "color"`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := findMarkerRegions([]byte(test.contents), htmlMarkers)
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+func TestFindMarkerRegions_Syntax(t *testing.T) {
+	asciidoc := "== Chart\n\n// helm-tool:begin name=properties template=asciidoc-table\nold\n// helm-tool:end\n"
+	regions, err := findMarkerRegions([]byte(asciidoc), markerSyntaxFor("README.adoc"))
+	require.NoError(t, err)
+	require.Len(t, regions, 1)
+	assert.Equal(t, "asciidoc-table", regions[0].template)
+	assert.Equal(t, "\nold\n", asciidoc[regions[0].start:regions[0].end])
+
+	rst := "Chart\n=====\n\n.. helm-tool:begin name=properties template=rst-table\n\nold\n\n.. helm-tool:end\n"
+	regions, err = findMarkerRegions([]byte(rst), markerSyntaxFor("index.rst"))
+	require.NoError(t, err)
+	require.Len(t, regions, 1)
+	assert.Equal(t, "rst-table", regions[0].template)
+	assert.Equal(t, "\n\nold\n\n", rst[regions[0].start:regions[0].end])
+}
+
+func TestFindMarkerRegions_CodeBlocks(t *testing.T) {
+	markdown := "Add the markers to the README:\n\n````markdown\n<!-- helm-tool:begin name=example -->\n```\n<!-- helm-tool:end -->\n````\n\n<!-- helm-tool:begin name=properties -->\n<!-- helm-tool:end -->\n\n~~~\n<!-- helm-tool:begin name=unclosed -->\n"
+	regions, err := findMarkerRegions([]byte(markdown), htmlMarkers)
+	require.NoError(t, err)
+	require.Len(t, regions, 1)
+	assert.Equal(t, "properties", regions[0].name)
+
+	asciidoc := "----\n// helm-tool:begin name=example\n// helm-tool:end\n----\n\n// helm-tool:begin name=properties\n// helm-tool:end\n"
+	regions, err = findMarkerRegions([]byte(asciidoc), asciidocMarkers)
+	require.NoError(t, err)
+	require.Len(t, regions, 1)
+	assert.Equal(t, "properties", regions[0].name)
+}
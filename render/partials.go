@@ -0,0 +1,127 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/cert-manager/helm-tool/parser"
+)
+
+// partialCommentExp matches a define block and the comment preceding it.
+var partialCommentExp = regexp.MustCompile(`(?:\{\{-?\s*/\*\s*((?:[^*]|\*+[^*/])*?)\s*\*/\s*-?\}\}\s*)?\{\{-?\s*define\s+"([^"]+)"`)
+
+// Partial is a named template defined by a template with a define block,
+// which can be overridden with a partials directory.
+type Partial struct {
+	Name string
+	// Description is the comment preceding the define block.
+	Description string
+}
+
+// partialFile is a file of a partials directory.
+type partialFile struct {
+	name string
+	text string
+}
+
+// readTemplate returns the text of the template.
+func readTemplate(templateName string) (string, error) {
+	file, err := openTemplate(templateName)
+	if err != nil {
+		return "", err
+	}
+
+	defer file.Close()
+
+	templateBytes, err := io.ReadAll(file)
+	if err != nil {
+		return "", err
+	}
+
+	return string(templateBytes), nil
+}
+
+// readPartials returns the .tpl files of the directory, ordered by name.
+func readPartials(dir string) ([]partialFile, error) {
+	if dir == "" {
+		return nil, nil
+	}
+
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("partials directory: %w", err)
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.tpl"))
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(paths)
+
+	files := make([]partialFile, 0, len(paths))
+	for _, path := range paths {
+		text, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		files = append(files, partialFile{name: filepath.Base(path), text: string(text)})
+	}
+
+	return files, nil
+}
+
+// ListPartials returns the partials defined by the template, ordered by name.
+func ListPartials(templateName string) ([]Partial, error) {
+	text, err := readTemplate(templateName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the text/template parser is needed to find the define blocks
+	tpl, err := template.New(templateName).Funcs(funcMap(&parser.Document{}, pathLinks{}, Options{})).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+
+	descriptions := map[string]string{}
+	for _, match := range partialCommentExp.FindAllStringSubmatch(text, -1) {
+		descriptions[match[2]] = strings.Join(strings.Fields(match[1]), " ")
+	}
+
+	var partials []Partial
+	for _, defined := range tpl.Templates() {
+		if defined.Name() == templateName {
+			continue
+		}
+
+		partials = append(partials, Partial{Name: defined.Name(), Description: descriptions[defined.Name()]})
+	}
+
+	slices.SortFunc(partials, func(a, b Partial) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return partials, nil
+}
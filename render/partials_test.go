@@ -0,0 +1,110 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender_Partials(t *testing.T) {
+	document := loadDocument(t, "# +docs:section=Main\n\n# The number of replicas\nreplicas: 1\n")
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "comment.tpl"), []byte(`{{ define "comment" }}
+> {{ .String }}
+{{- end }}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "ignored.txt"), []byte(`{{ define "property" }}{{ end }}`), 0600))
+
+	rendered, err := RenderWithOptions("markdown-table", document, Options{Partials: dir})
+	require.NoError(t, err)
+	assert.Contains(t, rendered, "<td>\n> The number of replicas\n\n</td>")
+	assert.Contains(t, rendered, "### Main")
+
+	// Only the overridden partials change
+	original, err := Render("markdown-table", document)
+	require.NoError(t, err)
+	assert.NotEqual(t, original, rendered)
+	assert.Contains(t, original, "<td>\n\nThe number of replicas\n\n</td>")
+}
+
+func TestRender_PartialsHTML(t *testing.T) {
+	document := loadDocument(t, "# +docs:section=Main\n\n# The number of replicas\nreplicas: 1\n")
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "header.tpl"), []byte(`{{ define "section-header" }}<h2 class="custom">{{ .Name }} & co</h2>{{ end }}`), 0600))
+
+	rendered, err := RenderWithOptions("html", document, Options{Partials: dir})
+	require.NoError(t, err)
+	assert.Contains(t, rendered, `<h2 class="custom">Main & co</h2>`)
+}
+
+func TestRender_PartialsErrors(t *testing.T) {
+	document := loadDocument(t, "replicas: 1\n")
+
+	_, err := RenderWithOptions("markdown-plain", document, Options{Partials: filepath.Join(t.TempDir(), "missing")})
+	assert.ErrorContains(t, err, "partials directory")
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.tpl"), []byte(`{{ define "comment" }}{{ .Missing`), 0600))
+	_, err = RenderWithOptions("markdown-plain", document, Options{Partials: dir})
+	assert.ErrorContains(t, err, "broken.tpl")
+}
+
+func TestListPartials(t *testing.T) {
+	for _, templateName := range []string{"markdown-plain", "markdown-table", "markdown-table-vertical", "asciidoc-table", "rst-table", "html"} {
+		partials, err := ListPartials(templateName)
+		require.NoError(t, err)
+
+		names := map[string]string{}
+		for _, partial := range partials {
+			assert.NotEmpty(t, partial.Description, "%s: %s", templateName, partial.Name)
+			names[partial.Name] = partial.Description
+		}
+
+		for _, name := range []string{"section-header", "property", "comment", "default"} {
+			assert.Contains(t, names, name, templateName)
+		}
+	}
+
+	partials, err := ListPartials("markdown-plain")
+	require.NoError(t, err)
+	assert.Contains(t, partials, Partial{Name: "path", Description: "A property path, linked to the property if it is documented"})
+}
+
+func TestRender_PartialsCommentSegment(t *testing.T) {
+	document := loadDocument(t, "# +docs:section=Main\n\n# The number of replicas\nreplicas: 1\n")
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "comment.tpl"), []byte(`{{ define "comment" }}
+{{ .Type }}: {{ .String }}
+{{- end }}
+{{ define "cell-comment" }}
+{{ .Type }}: {{ .String }}
+{{- end }}`), 0600))
+
+	// The comment partials get a comment segment in every template
+	for _, templateName := range []string{"markdown-plain", "markdown-table", "markdown-table-vertical", "asciidoc-table", "rst-table", "html"} {
+		rendered, err := RenderWithOptions(templateName, document, Options{Partials: dir})
+		require.NoError(t, err, templateName)
+		assert.Contains(t, rendered, "text: The number of replicas", templateName)
+	}
+}
@@ -17,30 +17,39 @@ limitations under the License.
 package render
 
 import (
+	"bytes"
 	"embed"
 	"errors"
+	"fmt"
+	htmltemplate "html/template"
 	"io"
 	"io/fs"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"text/template"
 
-	"github.com/Masterminds/sprig/v3"
-
+	"github.com/cert-manager/helm-tool/chart"
 	"github.com/cert-manager/helm-tool/parser"
 )
 
 //go:embed markdown-plain
 //go:embed markdown-table
 //go:embed markdown-table-vertical
+//go:embed asciidoc-table
+//go:embed rst-table
+//go:embed html
+//go:embed helm-set
+//go:embed chart-header
 var templates embed.FS
 
 // openTemplate resolves a template name to a readable file.
 //
 // Bare names (without a path separator) are resolved exclusively
 // against the embedded FS, which contains the built-in templates
-// (markdown-plain, markdown-table, markdown-table-vertical). This
+// (markdown-plain, markdown-table, markdown-table-vertical,
+// asciidoc-table, rst-table, html, helm-set, chart-header). This
 // prevents an attacker-controlled file in the working directory from
 // shadowing a built-in.
 //
@@ -63,54 +72,267 @@ func openTemplate(path string) (fs.File, error) {
 	return file, nil
 }
 
+// Options configures the rendering of the built-in templates.
+type Options struct {
+	// TOC adds a table of contents, linking every section and property.
+	TOC bool
+	// CollapseDefaults collapses defaults with more lines than this, 0 shows
+	// all defaults in full. See also +docs:defaultDisplay.
+	CollapseDefaults int
+
+	// Sort is the order of the properties within their section, the order of
+	// the values file by default.
+	Sort SortOrder
+	// Include and Exclude are path globs selecting the rendered properties,
+	// a glob matching an object also matches the properties it contains.
+	Include []string
+	Exclude []string
+	// Sections lists the names of the rendered sections, all sections are
+	// rendered if empty.
+	Sections []string
+
+	// Partials is a directory of .tpl files, whose define blocks override
+	// the partials of the template, eg. "comment" or "property".
+	Partials string
+
+	// Chart is the metadata of the chart, templates get it as .Chart. It is
+	// nil if the Chart.yaml file was not read.
+	Chart *chart.Metadata
+}
+
+// Data is the data templates are executed with, the document and the options.
+type Data struct {
+	*parser.Document
+	Options Options
+}
+
+// Chart returns the metadata of the chart of the options, so templates can
+// use .Chart.
+func (d Data) Chart() *chart.Metadata {
+	return d.Options.Chart
+}
+
+// Render renders the document with the template. HTML templates (the html
+// built-in, or files with a .html extension) are executed with html/template.
 func Render(templateName string, document *parser.Document) (string, error) {
-	tpl, err := openTemplate(templateName)
+	return RenderWithOptions(templateName, document, Options{})
+}
+
+// RenderWithOptions renders the document with the template, configured by the
+// options. The sections, sort order and globs of the options are applied to a
+// copy of the document first.
+func RenderWithOptions(templateName string, document *parser.Document, options Options) (string, error) {
+	document, err := filterDocument(document, options)
 	if err != nil {
 		return "", err
 	}
 
-	defer tpl.Close()
+	return render(templateName, document, newPathLinks(document), options)
+}
 
-	templateBytes, err := io.ReadAll(tpl)
+// render renders the document with the template, without filtering it. The
+// documented paths are linked with links, and the define blocks of the
+// partials directory of the options override the partials of the template.
+func render(templateName string, document *parser.Document, links pathLinks, options Options) (string, error) {
+	templateText, err := readTemplate(templateName)
 	if err != nil {
 		return "", err
 	}
 
-	funcMap := sprig.HermeticTxtFuncMap()
-	funcMap["indentWith"] = func(pad string, v string) string {
-		return pad + strings.ReplaceAll(v, "\n", "\n"+pad)
-	}
-
-	template, err := template.New(templateName).Funcs(funcMap).Parse(string(templateBytes))
+	partials, err := readPartials(options.Partials)
 	if err != nil {
 		return "", err
 	}
 
+	var tpl interface {
+		Execute(w io.Writer, data any) error
+	}
+	if isHTMLTemplate(templateName) {
+		htmlTpl, err := htmltemplate.New(templateName).Funcs(htmltemplate.FuncMap(funcMap(document, links, options))).Parse(templateText)
+		if err != nil {
+			return "", err
+		}
+
+		for _, partial := range partials {
+			if _, err := htmlTpl.New(partial.name).Parse(partial.text); err != nil {
+				return "", err
+			}
+		}
+		tpl = htmlTpl
+	} else {
+		textTpl, err := template.New(templateName).Funcs(funcMap(document, links, options)).Parse(templateText)
+		if err != nil {
+			return "", err
+		}
+
+		for _, partial := range partials {
+			if _, err := textTpl.New(partial.name).Parse(partial.text); err != nil {
+				return "", err
+			}
+		}
+		tpl = textTpl
+	}
+
 	var sb strings.Builder
-	if err := template.Execute(&sb, document); err != nil {
+	if err := tpl.Execute(&sb, Data{Document: document, Options: options}); err != nil {
 		return "", err
 	}
 
 	return sb.String(), nil
 }
 
-func Inject(path, templateName string, document *parser.Document, headerMatch, footerMatch *regexp.Regexp) error {
-	// Open the file
-	file, err := os.OpenFile(path, os.O_RDWR, 0666)
+// Inject renders the documentation into the file at path. If the file
+// contains helm-tool:begin and helm-tool:end markers, every marked region is
+// rendered with its own template and sections. Otherwise the documentation is
+// injected between the headerMatch and footerMatch matches.
+//
+// The file is replaced atomically, keeping its mode and line endings, and is
+// left untouched if the documentation is up to date. The returned bool
+// reports whether the file changed.
+func Inject(path, templateName string, document *parser.Document, headerMatch, footerMatch *regexp.Regexp) (bool, error) {
+	return InjectWithOptions(path, templateName, document, headerMatch, footerMatch, Options{})
+}
+
+// InjectWithOptions injects the documentation into the file at path like
+// Inject, rendering it with the options.
+func InjectWithOptions(path, templateName string, document *parser.Document, headerMatch, footerMatch *regexp.Regexp, options Options) (bool, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+
+	filtered, err := filterDocument(document, options)
+	if err != nil {
+		return false, err
+	}
+
+	original, err := os.ReadFile(path)
 	if err != nil {
+		return false, err
+	}
+
+	// Work on LF line endings, and restore CRLF line endings when writing
+	crlf := usesCRLF(original)
+	fileContents := original
+	if crlf {
+		fileContents = bytes.ReplaceAll(original, []byte("\r\n"), []byte("\n"))
+	}
+
+	syntax := markerSyntaxFor(path)
+	regions, err := findMarkerRegions(fileContents, syntax)
+	if err != nil {
+		return false, err
+	}
+
+	markers := len(regions) > 0
+	if !markers {
+		headerRegion, err := findHeaderRegion(fileContents, headerMatch, footerMatch)
+		if err != nil {
+			return false, err
+		}
+		regions = []region{headerRegion}
+	}
+
+	var result bytes.Buffer
+	last := 0
+	for _, region := range regions {
+		regionTemplate := region.template
+		if regionTemplate == "" {
+			regionTemplate = templateName
+		}
+
+		// The sections of a region can have been removed by the options, so
+		// they are checked against the unfiltered document
+		if err := checkSections(document, region.sections); err != nil {
+			return false, fmt.Errorf("region %q: %w", region.name, err)
+		}
+		regionDocument := selectSections(filtered, region.sections)
+
+		renderedDocument, err := render(regionTemplate, regionDocument, newPathLinks(regionDocument), options)
+		if err != nil {
+			return false, fmt.Errorf("could not render documentation from template %q: %w", regionTemplate, err)
+		}
+		renderedDocument = strings.ReplaceAll(renderedDocument, "\r\n", "\n")
+
+		result.Write(fileContents[last:region.start])
+		if markers {
+			result.WriteString(syntax.separator + strings.Trim(renderedDocument, "\n") + syntax.separator)
+		} else {
+			result.WriteString(renderedDocument + "\n")
+		}
+		last = region.end
+	}
+	result.Write(fileContents[last:])
+
+	output := result.Bytes()
+	if crlf {
+		output = bytes.ReplaceAll(output, []byte("\n"), []byte("\r\n"))
+	}
+
+	if bytes.Equal(output, original) {
+		return false, nil
+	}
+
+	if err := writeFileAtomic(path, output, info.Mode().Perm()); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// usesCRLF reports whether most lines of the contents end with CRLF.
+func usesCRLF(contents []byte) bool {
+	crlf := bytes.Count(contents, []byte("\r\n"))
+	return crlf > 0 && crlf*2 >= bytes.Count(contents, []byte("\n"))
+}
+
+// writeFileAtomic writes the data to a temporary file next to path and renames
+// it into place, so the file is never left partially written. Symlinks are
+// resolved first, so the file they point to is replaced, not the symlink. The
+// file is created if it does not exist.
+func writeFileAtomic(path string, data []byte, perm fs.FileMode) (err error) {
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		path = resolved
+	} else if !errors.Is(err, fs.ErrNotExist) {
 		return err
 	}
 
-	// Read the contents
-	fileContents, err := io.ReadAll(file)
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".tmp-*")
 	if err != nil {
 		return err
 	}
+	defer func() {
+		if err != nil {
+			tmp.Close()
+			os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		return err
+	}
+
+	if err := tmp.Chmod(perm); err != nil {
+		return err
+	}
+
+	if err := tmp.Sync(); err != nil {
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
 
+	return os.Rename(tmp.Name(), path)
+}
+
+// findHeaderRegion returns the region between the header and the footer.
+func findHeaderRegion(fileContents []byte, headerMatch, footerMatch *regexp.Regexp) (region, error) {
 	// Find the start of where to inject
 	startIdx := headerMatch.FindIndex(fileContents)
 	if startIdx == nil {
-		return errors.New("could not find parameters tag")
+		return region{}, errors.New("could not find parameters tag")
 	}
 	start := startIdx[1]
 
@@ -121,20 +343,5 @@ func Inject(path, templateName string, document *parser.Document, headerMatch, f
 		end = start + endIdx[0]
 	}
 
-	renderedDocument, err := Render(templateName, document)
-	if err != nil {
-		return errors.New("could not render documentation from template")
-	}
-
-	header := fileContents[:start]
-	content := []byte(renderedDocument + "\n")
-	footer := fileContents[end:]
-
-	file.Truncate(0)
-	file.Seek(0, 0)
-	file.Write(header)
-	file.Write(content)
-	file.Write(footer)
-
-	return nil
+	return region{start: start, end: end}, nil
 }
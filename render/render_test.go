@@ -0,0 +1,112 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/helm-tool/parser"
+)
+
+var (
+	testHeader = regexp.MustCompile(`(?m)^##\s+Parameters *$`)
+	testFooter = regexp.MustCompile(`(?m)^##?\s+.*$`)
+)
+
+func loadDocument(t *testing.T, content string) *parser.Document {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "values.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	document, err := parser.Load(path, false)
+	require.NoError(t, err)
+	return document
+}
+
+func TestInject(t *testing.T) {
+	document := loadDocument(t, "# The number of replicas.\nreplicas: 1\n")
+
+	path := filepath.Join(t.TempDir(), "README.md")
+	original := "# Chart\r\n\r\n## Parameters\r\n\r\nstale\r\n\r\n## License\r\n"
+	require.NoError(t, os.WriteFile(path, []byte(original), 0640))
+
+	changed, err := Inject(path, "markdown-plain", document, testHeader, testFooter)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(contents), "The number of replicas.")
+	assert.NotContains(t, string(contents), "stale")
+	assert.Equal(t, strings.Count(string(contents), "\n"), strings.Count(string(contents), "\r\n"), "line endings are kept")
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0640), info.Mode().Perm())
+
+	changed, err = Inject(path, "markdown-plain", document, testHeader, testFooter)
+	require.NoError(t, err)
+	assert.False(t, changed)
+}
+
+func TestInject_Symlink(t *testing.T) {
+	document := loadDocument(t, "# The number of replicas.\nreplicas: 1\n")
+
+	dir := t.TempDir()
+	target := filepath.Join(dir, "docs.md")
+	link := filepath.Join(dir, "README.md")
+	require.NoError(t, os.WriteFile(target, []byte("## Parameters\n\n## License\n"), 0644))
+	require.NoError(t, os.Symlink("docs.md", link))
+
+	changed, err := Inject(link, "markdown-plain", document, testHeader, testFooter)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	info, err := os.Lstat(link)
+	require.NoError(t, err)
+	assert.Equal(t, os.ModeSymlink, info.Mode().Type(), "the symlink is kept")
+
+	contents, err := os.ReadFile(target)
+	require.NoError(t, err)
+	assert.Contains(t, string(contents), "The number of replicas.")
+}
+
+func TestInject_RenderError(t *testing.T) {
+	document := loadDocument(t, "replicas: 1\n")
+
+	dir := t.TempDir()
+	templatePath := filepath.Join(dir, "broken.tpl")
+	require.NoError(t, os.WriteFile(templatePath, []byte("{{ .Missing }}"), 0600))
+
+	path := filepath.Join(dir, "README.md")
+	original := "## Parameters\n\nkeep\n"
+	require.NoError(t, os.WriteFile(path, []byte(original), 0600))
+
+	_, err := Inject(path, templatePath, document, testHeader, testFooter)
+	require.ErrorContains(t, err, "can't evaluate field Missing")
+
+	contents, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, original, string(contents))
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/cert-manager/helm-tool/parser"
+)
+
+var (
+	shellSafeExp = regexp.MustCompile(`^[A-Za-z0-9_./:=@%+-]*$`)
+
+	// setValueEscaper escapes the characters that separate values in Helm's
+	// --set and --set-string flags.
+	setValueEscaper = strings.NewReplacer(`\`, `\\`, `,`, `\,`)
+)
+
+// setFlag returns the Helm flag that sets the property to its default value,
+// quoted for the shell. Strings use --set-string so they are not converted to
+// numbers or booleans, and arrays and objects use --set-json.
+func setFlag(property parser.Property) string {
+	var value any
+	if property.Default != "" {
+		if err := yaml.Unmarshal([]byte(property.Default), &value); err != nil {
+			value = property.Default
+		}
+	}
+
+	if value == nil {
+		value = zeroValue(property.Type)
+	}
+
+	return valueFlag(property.Path.SetKey(), property.Type, value, property.Default)
+}
+
+// setIndexFlags returns the Helm flags that set the items of an array property
+// to its default value one by one, using the array index syntax. Only arrays
+// of scalars are supported, it returns nothing for other properties.
+func setIndexFlags(property parser.Property) []string {
+	if property.Type != parser.TypeArray {
+		return nil
+	}
+
+	var items []any
+	if err := yaml.Unmarshal([]byte(property.Default), &items); err != nil {
+		return nil
+	}
+
+	flags := make([]string, 0, len(items))
+	for idx, item := range items {
+		switch item.(type) {
+		case string, bool, int, int64, uint64, float64:
+			flags = append(flags, valueFlag(property.Path.WithIndex(idx).SetKey(), parser.TypeUnknown, item, fmt.Sprint(item)))
+		default:
+			return nil
+		}
+	}
+
+	return flags
+}
+
+// valueFlag returns the Helm flag that sets the key to the value, raw is the
+// YAML of the value for values that cannot be represented in JSON.
+func valueFlag(key string, typ parser.Type, value any, raw string) string {
+	switch value := value.(type) {
+	case string:
+		return "--set-string " + shellQuote(key+"="+setValueEscaper.Replace(value))
+	case bool, int, int64, uint64, float64:
+		// Strings that look like numbers or booleans, set with +docs:type
+		if typ == parser.TypeString {
+			return "--set-string " + shellQuote(fmt.Sprintf("%s=%v", key, value))
+		}
+
+		return "--set " + shellQuote(fmt.Sprintf("%s=%v", key, value))
+	default:
+		data, err := json.Marshal(value)
+		if err != nil {
+			// Timestamps and other values without a JSON representation
+			return "--set-string " + shellQuote(key+"="+setValueEscaper.Replace(raw))
+		}
+
+		return "--set-json " + shellQuote(key+"="+string(data))
+	}
+}
+
+// zeroValue returns the value used for properties without a default.
+func zeroValue(typ parser.Type) any {
+	switch typ {
+	case parser.TypeNumber:
+		return 0
+	case parser.TypeBool:
+		return false
+	case parser.TypeArray:
+		return []any{}
+	case parser.TypeObject:
+		return map[string]any{}
+	default:
+		return ""
+	}
+}
+
+// shellQuote quotes the argument for POSIX shells, if needed.
+func shellQuote(arg string) string {
+	if arg != "" && shellSafeExp.MatchString(arg) {
+		return arg
+	}
+
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
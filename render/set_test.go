@@ -0,0 +1,60 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetIndexFlags(t *testing.T) {
+	document := loadDocument(t, `
+# +docs:property
+args: ["--v=2", 3]
+# +docs:property
+volumes: [{name: a}]
+# +docs:type=string
+version: 1.10
+`)
+
+	properties := document.Sections[0].Properties
+	assert.Equal(t, []string{`--set-string 'args[0]=--v=2'`, `--set 'args[1]=3'`}, setIndexFlags(properties[0]))
+	assert.Empty(t, setIndexFlags(properties[1]))
+	assert.Equal(t, `--set-string version=1.1`, setFlag(properties[2]))
+}
+
+func TestRender_HelmSet(t *testing.T) {
+	document := loadDocument(t, `
+# +docs:section=Image
+
+image:
+  # The image tag
+  tag: v1
+
+# +docs:property
+extraArgs: ["--v=2"]
+`)
+
+	rendered, err := Render("helm-set", document)
+	require.NoError(t, err)
+	assert.Equal(t, "\n### Image\n\n```sh\n"+
+		"# image.tag (string)\n--set-string image.tag=v1\n"+
+		"# extraArgs (array)\n--set-json 'extraArgs=[\"--v=2\"]'\n--set-string 'extraArgs[0]=--v=2'\n"+
+		"```\n", rendered)
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/cert-manager/helm-tool/heuristics"
+	"github.com/cert-manager/helm-tool/parser"
+)
+
+// SplitOptions configures how the documentation is split into one file per
+// section.
+type SplitOptions struct {
+	Options
+
+	// FilenamePattern is a template for the file name of a section page,
+	// executed with the Page, eg. "{{ .Weight }}-{{ .Slug }}.md".
+	FilenamePattern string
+	// IndexFile is the file name of the index page linking all sections, no
+	// index is written if empty.
+	IndexFile string
+	// UntitledSection is the title of the section of the properties before
+	// the first +docs:section tag.
+	UntitledSection string
+}
+
+// Page is a page of split documentation.
+type Page struct {
+	// Title is the name of the section.
+	Title string `yaml:"title"`
+	// Weight is the position of the page, starting at 1, following the order
+	// of the sections in the values file. Docs sites use it to order pages.
+	Weight int `yaml:"weight"`
+	// Description is the text of the section description.
+	Description string `yaml:"description,omitempty"`
+	// Slug is the title as a lower case identifier.
+	Slug string `yaml:"-"`
+	// Filename is the name of the file of the page.
+	Filename string `yaml:"-"`
+}
+
+// RenderSplit renders every section with properties to its own file in the
+// output directory, with front matter containing the title, weight and
+// description of the section, and a markdown index page linking all sections.
+// It returns the paths of the written files.
+//
+// The section pages are rendered without the name and the text of the
+// description of the section, as docs sites show them from the front matter.
+// Paths documented on other pages link to these pages.
+func RenderSplit(templateName string, document *parser.Document, outputDir string, options SplitOptions) ([]string, error) {
+	if !isMarkdownTemplate(templateName) {
+		return nil, fmt.Errorf("cannot split the documentation of template %q, only markdown templates are supported", templateName)
+	}
+
+	filenameTemplate, err := template.New("filename").Parse(options.FilenamePattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filename pattern: %w", err)
+	}
+
+	document, err = filterDocument(document, options.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return nil, err
+	}
+
+	// The pages are named first, so every page can link the properties
+	// documented on the other pages
+	var pages []Page
+	var sections []parser.Section
+	links := pathLinks{}
+	for _, section := range document.Sections {
+		if len(section.Properties) == 0 {
+			continue
+		}
+
+		page := Page{
+			Title:       section.Name,
+			Weight:      len(pages) + 1,
+			Description: commentText(section.Description),
+		}
+		if page.Title == "" {
+			page.Title = options.UntitledSection
+		}
+		page.Slug = strings.ToLower(slug(page.Title))
+
+		var filename strings.Builder
+		if err := filenameTemplate.Execute(&filename, page); err != nil {
+			return nil, fmt.Errorf("invalid filename pattern: %w", err)
+		}
+		page.Filename = filename.String()
+
+		if page.Filename == "" || page.Filename == options.IndexFile || filepath.Base(page.Filename) != page.Filename {
+			return nil, fmt.Errorf("section %q: invalid file name %q", page.Title, page.Filename)
+		}
+		for _, existing := range pages {
+			if existing.Filename == page.Filename {
+				return nil, fmt.Errorf("sections %q and %q have the same file name %q", existing.Title, page.Title, page.Filename)
+			}
+		}
+
+		for _, property := range section.Properties {
+			links[property.Path.String()] = page.Filename
+		}
+
+		pages = append(pages, page)
+		sections = append(sections, section)
+	}
+
+	var written []string
+	for i, page := range pages {
+		section := sections[i]
+
+		// The name and the text of the description are in the front matter,
+		// only the examples of the description are part of the page
+		section.Name = ""
+		section.Description.Segments = nonTextSegments(section.Description.Segments)
+
+		// The properties of the page link to their anchors on the page
+		pageLinks := maps.Clone(links)
+		for _, property := range section.Properties {
+			pageLinks[property.Path.String()] = ""
+		}
+
+		rendered, err := render(templateName, &parser.Document{Sections: []parser.Section{section}}, pageLinks, options.Options)
+		if err != nil {
+			return nil, fmt.Errorf("section %q: %w", page.Title, err)
+		}
+
+		path := filepath.Join(outputDir, page.Filename)
+		if err := writeFileAtomic(path, withFrontMatter(page, rendered), 0644); err != nil {
+			return nil, err
+		}
+
+		written = append(written, path)
+	}
+
+	if options.IndexFile != "" {
+		var index strings.Builder
+		for _, page := range pages {
+			fmt.Fprintf(&index, "- [%s](%s)", markdownEscape(page.Title), page.Filename)
+			if page.Description != "" {
+				fmt.Fprintf(&index, " - %s", strings.SplitN(page.Description, "\n", 2)[0])
+			}
+			index.WriteString("\n")
+		}
+
+		path := filepath.Join(outputDir, options.IndexFile)
+		if err := writeFileAtomic(path, withFrontMatter(Page{Title: "Values"}, index.String()), 0644); err != nil {
+			return nil, err
+		}
+		written = append(written, path)
+	}
+
+	return written, nil
+}
+
+// isMarkdownTemplate reports whether the template produces markdown, which is
+// the only format of split documentation as the index page and the front
+// matter are markdown. Custom templates are markdown unless their extension
+// says otherwise.
+func isMarkdownTemplate(templateName string) bool {
+	switch strings.ToLower(filepath.Ext(templateName)) {
+	case ".adoc", ".asciidoc", ".asc", ".rst", ".rest":
+		return false
+	}
+
+	switch filepath.Base(templateName) {
+	case "asciidoc-table", "rst-table":
+		return false
+	}
+
+	return !isHTMLTemplate(templateName)
+}
+
+// withFrontMatter prefixes the content with the YAML front matter of the page.
+func withFrontMatter(page Page, content string) []byte {
+	frontMatter, _ := yaml.Marshal(page)
+	return []byte("---\n" + string(frontMatter) + "---\n\n" + strings.TrimLeft(content, "\n"))
+}
+
+// nonTextSegments returns the segments that are not text, eg. examples.
+func nonTextSegments(segments []heuristics.CommentBlockSegment) []heuristics.CommentBlockSegment {
+	var kept []heuristics.CommentBlockSegment
+	for _, segment := range segments {
+		if segment.Type != heuristics.ContentTypeText {
+			kept = append(kept, segment)
+		}
+	}
+
+	return kept
+}
+
+// commentText returns the text segments of the comment, without examples and
+// tags.
+func commentText(comment parser.Comment) string {
+	var parts []string
+	for _, segment := range comment.Segments {
+		if segment.Type == heuristics.ContentTypeText {
+			parts = append(parts, segment.String())
+		}
+	}
+
+	return strings.TrimSpace(strings.Join(parts, "\n\n"))
+}
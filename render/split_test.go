@@ -0,0 +1,102 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package render
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const splitValues = `
+# The name override, see ` + "`webhook.replicas`" + `.
+# +docs:see=webhook.replicas
+nameOverride: ""
+
+# +docs:section=Web Hook
+# The webhook settings.
+
+webhook:
+  # The number of replicas.
+  replicas: 1
+`
+
+func TestRenderSplit(t *testing.T) {
+	document := loadDocument(t, splitValues)
+	outputDir := filepath.Join(t.TempDir(), "docs")
+
+	written, err := RenderSplit("markdown-plain", document, outputDir, SplitOptions{
+		FilenamePattern: "{{ .Weight }}-{{ .Slug }}.md",
+		IndexFile:       "_index.md",
+		UntitledSection: "General",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(outputDir, "1-general.md"),
+		filepath.Join(outputDir, "2-web-hook.md"),
+		filepath.Join(outputDir, "_index.md"),
+	}, written)
+
+	page, err := os.ReadFile(filepath.Join(outputDir, "2-web-hook.md"))
+	require.NoError(t, err)
+	assert.Regexp(t, "^---\ntitle: Web Hook\nweight: 2\ndescription: The webhook settings.\n---\n\n", string(page))
+	assert.Contains(t, string(page), "webhook.replicas")
+	assert.NotContains(t, string(page), "## Web Hook")
+	// The description is only in the front matter
+	assert.Equal(t, 1, strings.Count(string(page), "The webhook settings."))
+
+	// Paths documented on other pages link to these pages
+	page, err = os.ReadFile(filepath.Join(outputDir, "1-general.md"))
+	require.NoError(t, err)
+	assert.Contains(t, string(page), "The name override, see [`webhook.replicas`](2-web-hook.md#webhook-replicas).")
+	assert.Contains(t, string(page), "See also [`webhook.replicas`](2-web-hook.md#webhook-replicas).")
+
+	index, err := os.ReadFile(filepath.Join(outputDir, "_index.md"))
+	require.NoError(t, err)
+	assert.Equal(t, "---\ntitle: Values\nweight: 0\n---\n\n"+
+		"- [General](1-general.md)\n"+
+		"- [Web Hook](2-web-hook.md) - The webhook settings.\n", string(index))
+}
+
+func TestRenderSplit_InvalidFilename(t *testing.T) {
+	document := loadDocument(t, splitValues)
+
+	_, err := RenderSplit("markdown-plain", document, t.TempDir(), SplitOptions{
+		FilenamePattern: "values.md",
+		UntitledSection: "General",
+	})
+	assert.ErrorContains(t, err, `sections "General" and "Web Hook" have the same file name "values.md"`)
+
+	_, err = RenderSplit("markdown-plain", document, t.TempDir(), SplitOptions{
+		FilenamePattern: "../{{ .Slug }}.md",
+		UntitledSection: "General",
+	})
+	assert.ErrorContains(t, err, `section "General": invalid file name "../general.md"`)
+}
+
+func TestRenderSplit_NonMarkdownTemplate(t *testing.T) {
+	document := loadDocument(t, splitValues)
+
+	for _, templateName := range []string{"asciidoc-table", "rst-table", "html", "custom.adoc"} {
+		_, err := RenderSplit(templateName, document, t.TempDir(), SplitOptions{FilenamePattern: "{{ .Slug }}.md"})
+		assert.ErrorContains(t, err, "only markdown templates are supported", templateName)
+	}
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"fmt"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+
+	"github.com/cert-manager/helm-tool/parser"
+)
+
+// parseDefault parses the default value of a property and checks that it is
+// valid according to the schema generated for the property, so the schema
+// never rejects the chart's own defaults.
+func parseDefault(property *parser.Property) (any, error) {
+	var value any
+	if err := yaml.Unmarshal([]byte(property.Default), &value); err != nil {
+		return nil, fmt.Errorf("property %q: default %q is not valid YAML: %w", property.Path, property.Default, err)
+	}
+
+	// A null default means the property is unset
+	if value == nil {
+		return nil, nil
+	}
+
+	if !matchesType(property.Type, value) {
+		return nil, fmt.Errorf("property %q: default %q is not of type %s", property.Path, property.Default, property.Type)
+	}
+
+	if str, ok := value.(string); ok && property.Type == parser.TypeString && property.Format != parser.FormatNone {
+		if !property.Format.Matches(str) {
+			return nil, fmt.Errorf("property %q: default %q is not a valid %s", property.Path, str, property.Format)
+		}
+	}
+
+	return value, nil
+}
+
+func matchesType(typ parser.Type, value any) bool {
+	switch typ {
+	case parser.TypeString:
+		_, ok := value.(string)
+		return ok
+	case parser.TypeNumber:
+		switch value.(type) {
+		case int, int64, uint64, float64:
+			return true
+		}
+		return false
+	case parser.TypeBool:
+		_, ok := value.(bool)
+		return ok
+	case parser.TypeTimestamp:
+		switch value.(type) {
+		case time.Time, string:
+			return true
+		}
+		return false
+	case parser.TypeArray:
+		_, ok := value.([]any)
+		return ok
+	case parser.TypeObject:
+		_, ok := value.(map[string]any)
+		return ok
+	default:
+		// Properties of unknown type accept any value
+		return true
+	}
+}
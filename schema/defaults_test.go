@@ -0,0 +1,123 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderInvalidDefaults(t *testing.T) {
+	document := loadDocument(t, `
+# +docs:default=[unclosed
+list: []
+# +docs:default=yes please
+enabled: false
+# +docs:default=ten
+replicas: 1
+# +docs:default=soon
+timeout: 30s
+# +docs:default=~
+name: foo
+`)
+
+	_, err := Render(document)
+	require.Error(t, err)
+
+	message := err.Error()
+	assert.Contains(t, message, `property "list": default "[unclosed" is not valid YAML`)
+	assert.Contains(t, message, `property "enabled": default "yes please" is not of type bool`)
+	assert.Contains(t, message, `property "replicas": default "ten" is not of type number`)
+	assert.Contains(t, message, `property "timeout": default "soon" is not a valid duration`)
+	assert.NotContains(t, message, `"name"`)
+}
+
+func TestRenderFormats(t *testing.T) {
+	document := loadDocument(t, `
+image:
+  repository: quay.io/jetstack/cert-manager-controller
+timeout: 30s
+server: https://example.com
+email: admin@example.com
+# +docs:type=string
+typed: 30s
+`)
+
+	defs := renderDefinitions(t, document)
+
+	// Inferred formats are added as a format, or as a pattern for the
+	// formats JSON schema has no format for
+	assert.Contains(t, defs["helm-values.image.repository"], "pattern")
+	assert.Contains(t, defs["helm-values.timeout"], "pattern")
+	assert.NotContains(t, defs["helm-values.timeout"], "format")
+	assert.Equal(t, "uri", defs["helm-values.server"]["format"])
+	assert.Equal(t, "email", defs["helm-values.email"]["format"])
+	assert.NotContains(t, defs["helm-values.typed"], "pattern")
+}
+
+func TestRenderDefaultsMatchSchema(t *testing.T) {
+	document := loadDocument(t, `
+image:
+  # +docs:exclusiveWith=image.digest
+  tag: v1
+  digest: sha256:0000
+webhook:
+  tls:
+    enabled: true
+    # +docs:requiredWhen=webhook.tls.enabled
+    secretName: ""
+`)
+
+	// The values are only validated on demand
+	_, err := Render(document)
+	require.NoError(t, err)
+
+	_, err = RenderWithOptions(document, Options{ValidateValues: true})
+	require.Error(t, err)
+
+	message := err.Error()
+	assert.Contains(t, message, "default values do not match the schema")
+	assert.Contains(t, message, `image: 'not' failed`)
+	assert.Contains(t, message, `webhook.tls.secretName: minLength: got 0, want 1`)
+}
+
+func TestRenderUnexpandableValues(t *testing.T) {
+	// The values cannot be decoded, they are documented but not validated
+	document := loadDocument(t, "a: &a\n  b: *a\n")
+
+	_, err := RenderWithOptions(document, Options{ValidateValues: true})
+	require.NoError(t, err)
+}
+
+func TestRenderIgnoredValues(t *testing.T) {
+	document := loadDocument(t, `
+replicas: 1
+# +docs:ignore
+internal:
+  enabled: true
+`)
+
+	// Ignored values are not part of the schema, they only fail the
+	// validation of the values if it is requested
+	_, err := Render(document)
+	require.NoError(t, err)
+
+	_, err = RenderWithOptions(document, Options{ValidateValues: true})
+	require.ErrorContains(t, err, "internal")
+}
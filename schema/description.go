@@ -0,0 +1,142 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"regexp"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+	"k8s.io/kube-openapi/pkg/validation/spec"
+
+	"github.com/cert-manager/helm-tool/heuristics"
+	"github.com/cert-manager/helm-tool/parser"
+)
+
+var (
+	markdownLinkExp     = regexp.MustCompile(`\[([^\]]*)\]\(([^)]*)\)`)
+	markdownEmphasisExp = regexp.MustCompile("\\*\\*([^*]+)\\*\\*|__([^_]+)__|\\*([^*]+)\\*|`([^`]+)`")
+	sentenceEndExp      = regexp.MustCompile(`[.!?](\s|$)`)
+)
+
+// describe sets the human readable annotations of a property's schema: a
+// short title, a plain text description for generic tools and a markdown
+// description for editors that render markdown (eg. the VS Code YAML
+// extension).
+func describe(schema *spec.Schema, property *parser.Property) {
+	schema.Description = plainDescription(property.Description)
+	if title := title(property.Description); title != schema.Description {
+		schema.Title = title
+	}
+
+	markdown := markdownDescription(property.Description)
+	if markdown != "" && markdown != schema.Description {
+		setExtraProp(schema, "markdownDescription", markdown)
+	}
+
+	if property.IsDeprecated() {
+		setExtraProp(schema, "deprecationMessage", property.DeprecationMessage())
+	}
+
+	for name, value := range property.Description.Tags.WithPrefix(parser.TagExtensionPrefix) {
+		if schema.Extensions == nil {
+			schema.Extensions = spec.Extensions{}
+		}
+		schema.Extensions["x-"+name] = extensionValue(value)
+	}
+}
+
+// title returns the first sentence of the description.
+func title(comment parser.Comment) string {
+	for _, segment := range comment.Segments {
+		if segment.Type != heuristics.ContentTypeText {
+			continue
+		}
+
+		// The first sentence ends at the end of the first paragraph at the
+		// latest, but can be wrapped over several lines (eg. when the
+		// wrapped line is short enough to be kept as its own line).
+		text := stripMarkdown(strings.Join(segment.Contents, "\n"))
+		if paragraph, _, _ := strings.Cut(strings.TrimSpace(text), "\n\n"); paragraph != "" {
+			text = paragraph
+		}
+		text = strings.Join(strings.Fields(text), " ")
+		if loc := sentenceEndExp.FindStringIndex(text); loc != nil {
+			text = text[:loc[0]]
+		}
+
+		return strings.TrimSpace(text)
+	}
+
+	return ""
+}
+
+// plainDescription returns the description without markdown markup, code
+// examples are kept but indented instead of fenced.
+func plainDescription(comment parser.Comment) string {
+	var parts []string
+	for _, segment := range comment.Segments {
+		switch segment.Type {
+		case heuristics.ContentTypeText:
+			parts = append(parts, stripMarkdown(segment.String()))
+		case heuristics.ContentTypeYaml:
+			parts = append(parts, "    "+strings.ReplaceAll(segment.String(), "\n", "\n    "))
+		}
+	}
+
+	return strings.TrimSpace(strings.Join(parts, "\n\n"))
+}
+
+// markdownDescription returns the description as markdown, code examples are
+// fenced as yaml.
+func markdownDescription(comment parser.Comment) string {
+	var parts []string
+	for _, segment := range comment.Segments {
+		switch segment.Type {
+		case heuristics.ContentTypeText:
+			parts = append(parts, segment.String())
+		case heuristics.ContentTypeYaml:
+			parts = append(parts, "```yaml\n"+segment.String()+"\n```")
+		}
+	}
+
+	return strings.TrimSpace(strings.Join(parts, "\n\n"))
+}
+
+func stripMarkdown(text string) string {
+	text = markdownLinkExp.ReplaceAllString(text, "$1 ($2)")
+	// Only one of the groups matches, one per delimiter
+	return markdownEmphasisExp.ReplaceAllString(text, "$1$2$3$4")
+}
+
+// extensionValue parses the value of a +docs:x-<name> tag as YAML so that
+// booleans, numbers and lists keep their type, falling back to the raw string.
+func extensionValue(value string) any {
+	var parsed any
+	if err := yaml.Unmarshal([]byte(value), &parsed); err != nil || parsed == nil {
+		return value
+	}
+
+	return parsed
+}
+
+func setExtraProp(schema *spec.Schema, key string, value any) {
+	if schema.ExtraProps == nil {
+		schema.ExtraProps = map[string]any{}
+	}
+	schema.ExtraProps[key] = value
+}
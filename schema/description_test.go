@@ -0,0 +1,81 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderDescriptions(t *testing.T) {
+	document := loadDocument(t, `
+# Number of **replicas** to run. See [the docs](https://example.com) for
+# more information.
+#
+# For example:
+# replicas: 3
+#
+# +docs:deprecated=Use autoscaling instead
+# +docs:x-order=1
+# +docs:x-group=scaling
+replicas: 1
+
+# The log level
+# +docs:deprecated
+logLevel: 2
+`)
+
+	defs := renderDefinitions(t, document)
+
+	replicas := defs["helm-values.replicas"]
+	require.Equal(t, "Number of replicas to run", replicas["title"])
+	require.Equal(t, "Number of replicas to run. See the docs (https://example.com) for more information.\n\nFor example:\n\n    replicas: 3", replicas["description"])
+	require.Equal(t, "Number of **replicas** to run. See [the docs](https://example.com) for more information.\n\nFor example:\n\n```yaml\nreplicas: 3\n```", replicas["markdownDescription"])
+	require.Equal(t, "Use autoscaling instead", replicas["deprecationMessage"])
+	require.EqualValues(t, 1, replicas["x-order"])
+	require.Equal(t, "scaling", replicas["x-group"])
+
+	logLevel := defs["helm-values.logLevel"]
+	require.Equal(t, "The log level", logLevel["description"])
+	require.NotContains(t, logLevel, "title")
+	require.NotContains(t, logLevel, "markdownDescription")
+	require.Equal(t, "This property is deprecated.", logLevel["deprecationMessage"])
+}
+
+func TestRenderTitleWrapped(t *testing.T) {
+	document := loadDocument(t, `
+# Number of replicas of the webhook, scaled with the
+# Horizontal Pod Autoscaler.
+replicas: 1
+
+# The log level
+#
+# Lower levels log less.
+logLevel: 2
+`)
+
+	defs := renderDefinitions(t, document)
+	require.Equal(t, "Number of replicas of the webhook, scaled with the Horizontal Pod Autoscaler", defs["helm-values.replicas"]["title"])
+	require.Equal(t, "The log level", defs["helm-values.logLevel"]["title"])
+}
+
+func TestStripMarkdown(t *testing.T) {
+	require.Equal(t, "a bold, em, strong and code_span", stripMarkdown("a **bold**, *em*, __strong__ and `code_span`"))
+	require.Equal(t, "*foo_ and snake_case_name", stripMarkdown("*foo_ and snake_case_name"))
+	require.Equal(t, "__bar** baz", stripMarkdown("__bar** baz"))
+}
@@ -0,0 +1,253 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+
+	"github.com/cert-manager/helm-tool/parser"
+	"github.com/cert-manager/helm-tool/paths"
+	"github.com/cert-manager/helm-tool/refs"
+)
+
+var invalidDefinitionCharsExp = regexp.MustCompile(`[^A-Za-z0-9_-]+`)
+
+// refBundler resolves +docs:ref references to shared files into JSON schema
+// references. Referenced schema files are either bundled into the
+// definitions or referenced externally, referenced values files are always
+// bundled as they are not schemas themselves.
+type refBundler struct {
+	external bool
+
+	// prefixes holds the definition prefix of every bundled file
+	prefixes map[string]string
+	// schemas holds the raw contents of every loaded schema file
+	schemas map[string]map[string]any
+	// values holds the definitions generated for every loaded values file
+	values map[string]spec.Definitions
+}
+
+// refRoot is a property that is replaced by a reference, either because it is
+// tagged with +docs:ref itself or because it is the object that is tagged.
+type refRoot struct {
+	path paths.Path
+	ref  refs.Ref
+}
+
+// applyRefs replaces the definitions of all referenced properties with a
+// reference to the shared definition. Definitions of properties below a
+// referenced object are removed, the shared definition describes them.
+func (b *refBundler) applyRefs(definitions spec.Definitions, document *parser.Document) error {
+	for _, root := range refRoots(document) {
+		target, err := b.target(definitions, root.ref)
+		if err != nil {
+			return fmt.Errorf("property %q: %w", root.path, err)
+		}
+
+		name := prefixName(root.path.String())
+		for definitionName := range definitions {
+			if strings.HasPrefix(definitionName, name+".") || strings.HasPrefix(definitionName, name+"[") {
+				delete(definitions, definitionName)
+			}
+		}
+
+		// Keep the local annotations, only the structure is shared. The
+		// reference is wrapped in allOf as draft-07 ignores the siblings
+		// of a $ref.
+		local := definitions[name]
+		definitions[name] = spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Title:       local.Title,
+				Description: local.Description,
+				Default:     local.Default,
+				AllOf: []spec.Schema{{SchemaProps: spec.SchemaProps{
+					Ref: spec.MustCreateRef(target),
+				}}},
+			},
+			VendorExtensible: local.VendorExtensible,
+			ExtraProps:       local.ExtraProps,
+		}
+	}
+
+	return nil
+}
+
+// refRoots returns the outermost referenced properties of the document.
+func refRoots(document *parser.Document) []refRoot {
+	var roots []refRoot
+	for _, section := range document.Sections {
+		for _, property := range section.Properties {
+			if property.Ref == nil {
+				continue
+			}
+
+			root := refRoot{
+				path: property.Path[:len(property.Path)-len(property.Ref.Path)],
+				ref:  *property.Ref,
+			}
+			root.ref.Path = nil
+
+			nested := false
+			for i := 0; i < len(roots); i++ {
+				switch {
+				case roots[i].path.IsSubPathOf(root.path):
+					nested = true
+				case root.path.IsSubPathOf(roots[i].path):
+					roots = append(roots[:i], roots[i+1:]...)
+					i--
+				}
+			}
+			if !nested {
+				roots = append(roots, root)
+			}
+		}
+	}
+
+	return roots
+}
+
+// target returns the JSON schema reference for the shared definition,
+// bundling the referenced file into the definitions if needed.
+func (b *refBundler) target(definitions spec.Definitions, ref refs.Ref) (string, error) {
+	location := ref.Location()
+
+	if b.schemas == nil {
+		b.prefixes = map[string]string{}
+		b.schemas = map[string]map[string]any{}
+		b.values = map[string]spec.Definitions{}
+	}
+
+	if _, loaded := b.schemas[location]; !loaded && b.values[location] == nil {
+		kind, raw, err := refs.Load(location)
+		if err != nil {
+			return "", err
+		}
+
+		switch kind {
+		case refs.KindSchema:
+			b.schemas[location] = raw
+		case refs.KindValues:
+			shared, err := parser.Load(location, true)
+			if err != nil {
+				return "", err
+			}
+
+			// References in the shared file are not followed, which also
+			// prevents reference cycles
+			sharedDefinitions, err := buildDefinitions(shared, nil)
+			if err != nil {
+				return "", fmt.Errorf("could not generate schema for %q: %w", location, err)
+			}
+			b.values[location] = sharedDefinitions
+		}
+	}
+
+	if sharedDefinitions, ok := b.values[location]; ok {
+		return b.bundleValues(definitions, location, sharedDefinitions, ref)
+	}
+
+	raw := b.schemas[location]
+	pointer, err := refs.SchemaPointer(raw, ref)
+	if err != nil {
+		return "", err
+	}
+
+	if b.external {
+		return ref.File + "#" + pointer, nil
+	}
+
+	prefix, bundled := b.prefixes[location]
+	if !bundled {
+		// The raw document is kept to resolve pointers, embed a fresh copy
+		_, document, err := refs.Load(location)
+		if err != nil {
+			return "", err
+		}
+
+		prefix = b.prefix(location)
+		root, err := embedSchema(definitions, prefix, document)
+		if err != nil {
+			return "", fmt.Errorf("could not embed %q: %w", location, err)
+		}
+		definitions[prefix] = root
+	}
+
+	return embeddedRef(prefix, "#"+pointer), nil
+}
+
+// bundleValues copies the definition generated for the referenced value, and
+// the definitions of the values below it, into the definitions.
+func (b *refBundler) bundleValues(definitions spec.Definitions, location string, sharedDefinitions spec.Definitions, ref refs.Ref) (string, error) {
+	targetName := prefixName(ref.ValuesPath().String())
+	if _, ok := sharedDefinitions[targetName]; !ok {
+		return "", fmt.Errorf("%s: value %q is not documented in %q", ref, ref.ValuesPath(), location)
+	}
+
+	prefix, bundled := b.prefixes[location]
+	if !bundled {
+		prefix = b.prefix(location)
+	}
+
+	for name, definition := range sharedDefinitions {
+		if name != targetName && !strings.HasPrefix(name, targetName+".") && !strings.HasPrefix(name, targetName+"[") {
+			continue
+		}
+
+		raw, err := toRaw(definition)
+		if err != nil {
+			return "", err
+		}
+		rewriteRefs(raw, func(ref string) string {
+			return embeddedRef(prefix, ref)
+		})
+
+		converted, err := toSchema(raw)
+		if err != nil {
+			return "", err
+		}
+		definitions[prefix+"."+name] = converted
+	}
+
+	return "#/$defs/" + prefix + "." + targetName, nil
+}
+
+// prefix returns a unique definition prefix for a bundled file, based on the
+// name of the file.
+func (b *refBundler) prefix(location string) string {
+	base := strings.TrimSuffix(filepath.Base(location), filepath.Ext(location))
+	base = "ref." + invalidDefinitionCharsExp.ReplaceAllString(base, "-")
+
+	prefix := base
+	for i := 2; ; i++ {
+		taken := false
+		for _, existing := range b.prefixes {
+			taken = taken || existing == prefix
+		}
+		if !taken {
+			break
+		}
+		prefix = fmt.Sprintf("%s-%d", base, i)
+	}
+
+	b.prefixes[location] = prefix
+	return prefix
+}
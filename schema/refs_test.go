@@ -0,0 +1,117 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/helm-tool/parser"
+)
+
+func TestRender_Refs(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"shared/common.schema.json": `{
+			"$schema": "http://json-schema.org/draft-07/schema#",
+			"definitions": {
+				"image": {
+					"type": "object",
+					"properties": {
+						"repository": {"type": "string", "description": "The image repository."},
+						"tag": {"type": "string"}
+					}
+				}
+			}
+		}`,
+		"shared/values.yaml": `
+# Monitoring of the chart.
+serviceMonitor:
+  # Create a ServiceMonitor.
+  enabled: false
+`,
+		"values.yaml": `
+# +docs:ref=shared/common.schema.json#/definitions/image
+image:
+  repository: example.com/app
+  # The tag of the image.
+  tag: v1
+
+# +docs:ref=shared/values.yaml#/serviceMonitor
+serviceMonitor:
+  enabled: true
+`,
+	})
+
+	document, err := parser.Load(filepath.Join(dir, "values.yaml"), true)
+	require.NoError(t, err)
+
+	descriptions := map[string]string{}
+	for _, section := range document.Sections {
+		for _, property := range section.Properties {
+			descriptions[property.Path.String()] = property.Description.String()
+		}
+	}
+	assert.Equal(t, "The image repository.", descriptions["image.repository"])
+	assert.Equal(t, "The tag of the image.", descriptions["image.tag"])
+	assert.Equal(t, "Create a ServiceMonitor.", descriptions["serviceMonitor.enabled"])
+
+	defs := func(options Options) map[string]map[string]any {
+		rendered, err := RenderWithOptions(document, options)
+		require.NoError(t, err)
+
+		var result struct {
+			Defs map[string]map[string]any `json:"$defs"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(rendered), &result))
+		return result.Defs
+	}
+
+	bundled := defs(Options{})
+	assert.Equal(t, []any{map[string]any{"$ref": "#/$defs/ref.common-schema.image"}}, bundled["helm-values.image"]["allOf"])
+	assert.Equal(t, "string", bundled["ref.common-schema.image"]["properties"].(map[string]any)["tag"].(map[string]any)["type"])
+	assert.NotContains(t, bundled, "helm-values.image.tag")
+	assert.Equal(t, []any{map[string]any{"$ref": "#/$defs/ref.values.helm-values.serviceMonitor"}}, bundled["helm-values.serviceMonitor"]["allOf"])
+	assert.Contains(t, bundled, "ref.values.helm-values.serviceMonitor.enabled")
+
+	external := defs(Options{ExternalRefs: true})
+	assert.Equal(t, []any{map[string]any{"$ref": "shared/common.schema.json#/definitions/image"}}, external["helm-values.image"]["allOf"])
+	assert.NotContains(t, external, "ref.common-schema.image")
+	// Values files are not schemas, they are always bundled
+	assert.Contains(t, external, "ref.values.helm-values.serviceMonitor")
+}
+
+func TestRender_RefMissingValue(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"shared.yaml": "image: {}\n",
+		"values.yaml": `
+# +docs:ref=shared.yaml#/resources
+resources: {}
+`,
+	})
+
+	document, err := parser.Load(filepath.Join(dir, "values.yaml"), true)
+	require.NoError(t, err)
+
+	_, err = Render(document)
+	require.ErrorContains(t, err, "is not documented")
+}
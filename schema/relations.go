@@ -0,0 +1,180 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"fmt"
+	"slices"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+
+	"github.com/cert-manager/helm-tool/parser"
+	"github.com/cert-manager/helm-tool/paths"
+)
+
+// relations collects the conditional constraints (+docs:requiredWhen and
+// +docs:exclusiveWith) that have to be attached to the definition of the
+// closest common ancestor of the related properties.
+type relations struct {
+	// allOf lists the extra subschemas per definition name.
+	allOf map[string][]spec.Schema
+}
+
+func buildRelations(document *parser.Document) (relations, error) {
+	result := relations{
+		allOf: map[string][]spec.Schema{},
+	}
+
+	index := map[string]parser.Property{}
+	for _, section := range document.Sections {
+		for _, property := range section.Properties {
+			index[property.Path.String()] = property
+		}
+	}
+
+	seenExclusions := map[[2]string]bool{}
+	for _, section := range document.Sections {
+		for _, property := range section.Properties {
+			for _, condition := range property.RequiredWhen {
+				conditionProperty, ok := index[condition.String()]
+				if !ok {
+					return relations{}, fmt.Errorf("property %q is required when %q is set, but %q is not documented", property.Path, condition, condition)
+				}
+
+				ancestor, propertyRel, conditionRel, err := splitRelation(property.Path, condition)
+				if err != nil {
+					return relations{}, err
+				}
+
+				name := prefixName(ancestor.String())
+				result.allOf[name] = append(result.allOf[name], ifThen(
+					setChain(conditionRel, conditionProperty.Type),
+					setChain(propertyRel, property.Type),
+				))
+			}
+
+			for _, other := range property.ExclusiveWith {
+				otherProperty, ok := index[other.String()]
+				if !ok {
+					return relations{}, fmt.Errorf("property %q is exclusive with %q, but %q is not documented", property.Path, other, other)
+				}
+
+				// The relation is symmetric, only emit it once per pair
+				key := [2]string{property.Path.String(), other.String()}
+				slices.Sort(key[:])
+				if seenExclusions[key] {
+					continue
+				}
+				seenExclusions[key] = true
+
+				ancestor, propertyRel, otherRel, err := splitRelation(property.Path, other)
+				if err != nil {
+					return relations{}, err
+				}
+
+				name := prefixName(ancestor.String())
+				result.allOf[name] = append(result.allOf[name], spec.Schema{SchemaProps: spec.SchemaProps{
+					Not: &spec.Schema{SchemaProps: spec.SchemaProps{
+						AllOf: []spec.Schema{setChain(propertyRel, property.Type), setChain(otherRel, otherProperty.Type)},
+					}},
+				}})
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// apply attaches the collected relations to the matching definitions.
+func (r relations) apply(definitions spec.Definitions) {
+	for name, schemas := range r.allOf {
+		definition := definitions[name]
+		definition.AllOf = append(definition.AllOf, schemas...)
+		definitions[name] = definition
+	}
+}
+
+// splitRelation returns the closest object containing both paths, together
+// with the paths relative to that object.
+func splitRelation(a, b paths.Path) (paths.Path, paths.Path, paths.Path, error) {
+	if a.IsSubPathOf(b) || b.IsSubPathOf(a) {
+		return nil, nil, nil, fmt.Errorf("cannot relate %q to %q, one contains the other", a, b)
+	}
+
+	ancestor := paths.Path{}
+	for i := 0; i < len(a)-1 && i < len(b)-1 && a[i] == b[i]; i++ {
+		ancestor = a[:i+1]
+	}
+
+	aRel, bRel := a[len(ancestor):], b[len(ancestor):]
+	for _, rel := range []paths.Path{aRel, bRel} {
+		if slices.ContainsFunc(rel, paths.IsArrayPathComponent) {
+			return nil, nil, nil, fmt.Errorf("cannot relate %q to %q, relations across array items are not supported", a, b)
+		}
+	}
+
+	return ancestor, aRel, bRel, nil
+}
+
+// propertyChain nests the leaf schema under the relative path, requiring
+// every object on the way to be present.
+func propertyChain(rel paths.Path, leaf spec.Schema) spec.Schema {
+	schema := leaf
+	for i := len(rel) - 1; i >= 0; i-- {
+		name := paths.SegmentString(rel[i])
+		schema = spec.Schema{SchemaProps: spec.SchemaProps{
+			Properties: map[string]spec.Schema{name: schema},
+			Required:   []string{name},
+		}}
+	}
+
+	return schema
+}
+
+// setChain returns a schema that only matches if the property at the relative
+// path is set. Helm validates the values merged with the defaults, so a
+// property with a default is always present: empty strings, arrays and
+// objects, false and null are considered unset.
+func setChain(rel paths.Path, typ parser.Type) spec.Schema {
+	one := int64(1)
+
+	var leaf spec.Schema
+	switch typ {
+	case parser.TypeString, parser.TypeTimestamp:
+		leaf.Type = []string{"string"}
+		leaf.MinLength = &one
+	case parser.TypeArray:
+		leaf.Type = []string{"array"}
+		leaf.MinItems = &one
+	case parser.TypeObject:
+		leaf.Type = []string{"object"}
+		leaf.MinProperties = &one
+	case parser.TypeBool:
+		leaf.Enum = []any{true}
+	default:
+		leaf.Not = &spec.Schema{SchemaProps: spec.SchemaProps{Type: []string{"null"}}}
+	}
+
+	return propertyChain(rel, leaf)
+}
+
+func ifThen(condition, consequence spec.Schema) spec.Schema {
+	return spec.Schema{ExtraProps: map[string]any{
+		"if":   condition,
+		"then": consequence,
+	}}
+}
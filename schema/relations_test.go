@@ -0,0 +1,148 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v3"
+
+	"github.com/cert-manager/helm-tool/parser"
+)
+
+func loadDocument(t *testing.T, content string) *parser.Document {
+	t.Helper()
+	f, err := os.CreateTemp(t.TempDir(), "values-*.yaml")
+	require.NoError(t, err)
+	_, err = f.WriteString(content)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	document, err := parser.Load(f.Name(), true)
+	require.NoError(t, err)
+	return document
+}
+
+func renderDefinitions(t *testing.T, document *parser.Document) map[string]map[string]any {
+	t.Helper()
+	rendered, err := Render(document)
+	require.NoError(t, err)
+
+	var result struct {
+		Defs map[string]map[string]any `json:"$defs"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(rendered), &result))
+	return result.Defs
+}
+
+func TestRenderRequiredWhen(t *testing.T) {
+	document := loadDocument(t, `
+webhook:
+  tls:
+    enabled: false
+    # +docs:requiredWhen=webhook.tls.enabled
+    secretName: ""
+    # +docs:requiredWhen=webhook.tls.secretName
+    secretNamespace: ""
+`)
+
+	defs := renderDefinitions(t, document)
+	tls := defs["helm-values.webhook.tls"]
+
+	require.JSONEq(t, `[{
+		"if": {"properties": {"enabled": {"enum": [true]}}, "required": ["enabled"]},
+		"then": {"properties": {"secretName": {"type": "string", "minLength": 1}}, "required": ["secretName"]}
+	}, {
+		"if": {"properties": {"secretName": {"type": "string", "minLength": 1}}, "required": ["secretName"]},
+		"then": {"properties": {"secretNamespace": {"type": "string", "minLength": 1}}, "required": ["secretNamespace"]}
+	}]`, mustJSON(t, tls["allOf"]))
+	require.NotContains(t, tls, "dependentRequired")
+}
+
+func TestRenderExclusiveWith(t *testing.T) {
+	document := loadDocument(t, `
+image:
+  # +docs:exclusiveWith=image.digest
+  tag: ""
+  digest: ""
+`)
+
+	defs := renderDefinitions(t, document)
+	require.JSONEq(t, `[{"not": {"allOf": [
+		{"properties": {"tag": {"type": "string", "minLength": 1}}, "required": ["tag"]},
+		{"properties": {"digest": {"type": "string", "minLength": 1}}, "required": ["digest"]}
+	]}}]`, mustJSON(t, defs["helm-values.image"]["allOf"]))
+
+	// The relation is documented on both sides
+	require.Len(t, document.Sections[0].Properties[1].ExclusiveWith, 1)
+	require.Equal(t, "image.tag", document.Sections[0].Properties[1].ExclusiveWith[0].String())
+}
+
+func TestRenderRelationsAcceptDefaults(t *testing.T) {
+	document := loadDocument(t, `
+image:
+  # +docs:exclusiveWith=image.digest
+  tag: ""
+  digest: ""
+webhook:
+  tls:
+    enabled: false
+    # +docs:requiredWhen=webhook.tls.enabled
+    secretName: ""
+`)
+
+	rendered, err := Render(document)
+	require.NoError(t, err)
+
+	values, err := document.Values()
+	require.NoError(t, err)
+	errs, err := validateValues(rendered, values)
+	require.NoError(t, err)
+	require.Empty(t, errs)
+
+	validate := func(values string) []error {
+		var value any
+		require.NoError(t, yaml.Unmarshal([]byte(values), &value))
+		errs, err := validateValues(rendered, value)
+		require.NoError(t, err)
+		return errs
+	}
+
+	require.Empty(t, validate(`{image: {tag: v1, digest: ""}, webhook: {tls: {enabled: true, secretName: tls}}}`))
+	require.NotEmpty(t, validate(`{image: {tag: v1, digest: "sha256:0"}}`))
+	require.NotEmpty(t, validate(`{webhook: {tls: {enabled: true, secretName: ""}}}`))
+}
+
+func TestRenderRelationToUnknownProperty(t *testing.T) {
+	document := loadDocument(t, `
+# +docs:requiredWhen=does.not.exist
+foo: ""
+`)
+
+	_, err := Render(document)
+	require.ErrorContains(t, err, "not documented")
+}
+
+func mustJSON(t *testing.T, v any) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	require.NoError(t, err)
+	return string(data)
+}
@@ -18,9 +18,9 @@ package schema
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 
-	"go.yaml.in/yaml/v3"
 	"k8s.io/kube-openapi/pkg/validation/spec"
 
 	"github.com/cert-manager/helm-tool/heuristics"
@@ -112,14 +112,89 @@ func buildTree(document *parser.Document) (treeLevel, error) {
 	return root, nil
 }
 
+// Options configures the generated schema.
+type Options struct {
+	// ChartDir is the directory of the chart. When set, the schemas of the
+	// dependencies listed in its Chart.yaml are nested under their name or
+	// alias.
+	ChartDir string
+	// ExternalRefs makes +docs:ref references to schema files relative
+	// references, instead of bundling the referenced definitions.
+	ExternalRefs bool
+	// ValidateValues validates the values file as a whole against the
+	// generated schema, as Helm does on install. Undocumented values, eg.
+	// the ones ignored with +docs:ignore, then have to be allowed by the
+	// schema.
+	ValidateValues bool
+}
+
 func Render(document *parser.Document) (string, error) {
-	tree, err := buildTree(document)
+	return RenderWithOptions(document, Options{})
+}
+
+// RenderWithSubcharts renders the schema with the schemas of the dependencies
+// of the chart in chartDir nested under their name or alias.
+func RenderWithSubcharts(document *parser.Document, chartDir string) (string, error) {
+	return RenderWithOptions(document, Options{ChartDir: chartDir})
+}
+
+// RenderWithOptions renders the schema of the document, configured by the
+// options.
+func RenderWithOptions(document *parser.Document, options Options) (string, error) {
+	definitions, err := buildDefinitions(document, &refBundler{external: options.ExternalRefs})
 	if err != nil {
 		return "", err
 	}
 
+	if options.ChartDir != "" {
+		if err := addSubcharts(definitions, options.ChartDir); err != nil {
+			return "", err
+		}
+	}
+
+	rendered, err := marshalDefinitions(definitions)
+	if err != nil {
+		return "", err
+	}
+
+	// Helm validates the values merged with the defaults, so the defaults
+	// have to match the schema as a whole, including the relations between
+	// properties. Values that cannot be expanded (eg. an anchor containing
+	// itself) and schemas referencing other files are not validated.
+	if !options.ValidateValues || options.ExternalRefs {
+		return rendered, nil
+	}
+	if values, err := document.Values(); err == nil && values != nil {
+		valueErrors, err := validateValues(rendered, values)
+		if err != nil {
+			return "", err
+		}
+		if len(valueErrors) > 0 {
+			return "", fmt.Errorf("default values do not match the schema:\n%w", errors.Join(valueErrors...))
+		}
+	}
+
+	return rendered, nil
+}
+
+// buildDefinitions generates the definitions for all documented properties,
+// references to shared definitions are resolved by the bundler, or ignored
+// if it is nil.
+func buildDefinitions(document *parser.Document, bundler *refBundler) (spec.Definitions, error) {
+	tree, err := buildTree(document)
+	if err != nil {
+		return nil, err
+	}
+
+	relations, err := buildRelations(document)
+	if err != nil {
+		return nil, err
+	}
+
 	definitions := spec.Definitions{}
 
+	// All invalid defaults are reported together, instead of one per run
+	var defaultErrors []error
 	tree.walk(func(level treeLevel) {
 		levelType := level.Type()
 
@@ -131,12 +206,17 @@ func Render(document *parser.Document) (string, error) {
 		}
 
 		if level.Property != nil {
-			newSchema.SchemaProps.Description = level.Property.Description.String()
+			describe(&newSchema, level.Property)
+
+			if levelType == parser.TypeString {
+				newSchema.SchemaProps.Format = level.Property.Format.SchemaFormat()
+				newSchema.SchemaProps.Pattern = level.Property.Format.SchemaPattern()
+			}
 
 			if level.Property.Default != "" {
-				var defaultValue any
-				if err := yaml.Unmarshal([]byte(level.Property.Default), &defaultValue); err != nil {
-					panic(err)
+				defaultValue, err := parseDefault(level.Property)
+				if err != nil {
+					defaultErrors = append(defaultErrors, err)
 				}
 				newSchema.SchemaProps.Default = defaultValue
 			}
@@ -176,6 +256,22 @@ func Render(document *parser.Document) (string, error) {
 		definitions[prefixName(level.Path.String())] = newSchema
 	})
 
+	if len(defaultErrors) > 0 {
+		return nil, fmt.Errorf("invalid default values:\n%w", errors.Join(defaultErrors...))
+	}
+
+	relations.apply(definitions)
+
+	if bundler != nil {
+		if err := bundler.applyRefs(definitions, document); err != nil {
+			return nil, err
+		}
+	}
+
+	return definitions, nil
+}
+
+func marshalDefinitions(definitions spec.Definitions) (string, error) {
 	type JsonSchema struct {
 		Schema string           `json:"$schema,omitempty"`
 		Ref    string           `json:"$ref,omitempty"`
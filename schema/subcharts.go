@@ -0,0 +1,274 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"k8s.io/kube-openapi/pkg/validation/spec"
+
+	"github.com/cert-manager/helm-tool/chart"
+	"github.com/cert-manager/helm-tool/parser"
+)
+
+// addSubcharts composes the schema of an umbrella chart. The schema of every
+// dependency listed in the chart's Chart.yaml is nested under the
+// dependency's name (or alias), and the global values of all charts are
+// merged into a single global definition.
+func addSubcharts(definitions spec.Definitions, chartDir string) error {
+	metadata, err := chart.Load(chartDir)
+	if err != nil {
+		return err
+	}
+
+	for _, dependency := range metadata.Dependencies {
+		subchart, err := chart.OpenSubchart(chartDir, dependency)
+		if err != nil {
+			return err
+		}
+
+		subchartSchema, err := loadSubchartSchema(subchart.Dir)
+		subchart.Close()
+		if err != nil {
+			return fmt.Errorf("could not load schema of dependency %q: %w", dependency.Name, err)
+		}
+
+		if err := embedSubchart(definitions, dependency.Key(), subchartSchema); err != nil {
+			return fmt.Errorf("could not embed schema of dependency %q: %w", dependency.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// loadSubchartSchema returns the raw JSON schema of a subchart, preferring the
+// values.schema.json shipped with the chart and falling back to generating one
+// from its values.yaml.
+func loadSubchartSchema(subchartDir string) (map[string]any, error) {
+	var raw []byte
+
+	schemaFile := filepath.Join(subchartDir, chart.SchemaFile)
+	valuesFile := filepath.Join(subchartDir, chart.ValuesFile)
+	switch contents, err := os.ReadFile(schemaFile); {
+	case err == nil:
+		raw = contents
+	case !os.IsNotExist(err):
+		return nil, err
+	default:
+		if _, err := os.Stat(valuesFile); os.IsNotExist(err) {
+			// A chart without values accepts anything
+			return map[string]any{}, nil
+		}
+
+		// An empty (or comment only) values.yaml has no document at all
+		document, err := parser.Load(valuesFile, true)
+		if errors.Is(err, io.EOF) {
+			return map[string]any{}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		rendered, err := Render(document)
+		if err != nil {
+			return nil, err
+		}
+
+		raw = []byte(rendered)
+	}
+
+	var result map[string]any
+	if err := json.Unmarshal(raw, &result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// embedSubchart adds the definitions of the subchart schema to the parent
+// definitions, nested under the subchart key.
+func embedSubchart(definitions spec.Definitions, key string, subchartSchema map[string]any) error {
+	prefix := "subchart." + key
+
+	root, err := embedSchema(definitions, prefix, subchartSchema)
+	if err != nil {
+		return err
+	}
+	definitions[prefix] = root
+
+	// Replace whatever the parent documented for the subchart key, the
+	// subchart schema is the authority on the structure of its values.
+	parentKey := prefixName(key)
+	for name := range definitions {
+		if name == parentKey || strings.HasPrefix(name, parentKey+".") {
+			delete(definitions, name)
+		}
+	}
+
+	parent := definitions[prefixName("")]
+	if parent.Properties == nil {
+		parent.Properties = map[string]spec.Schema{}
+	}
+	parent.Properties[key] = spec.Schema{SchemaProps: spec.SchemaProps{
+		Ref: spec.MustCreateRef("#/$defs/" + prefix),
+	}}
+	definitions[prefixName("")] = parent
+
+	mergeGlobals(definitions, root)
+
+	return nil
+}
+
+// embedSchema adds the definitions of a standalone schema document to the
+// definitions, all prefixed so they cannot clash with other definitions, and
+// returns the root of the document. Local references in the document are
+// rewritten to point to the embedded definitions, with the root of the
+// document expected to be stored as the prefix definition.
+func embedSchema(definitions spec.Definitions, prefix string, document map[string]any) (spec.Schema, error) {
+	rewriteRefs(document, func(ref string) string {
+		return embeddedRef(prefix, ref)
+	})
+
+	for _, defsKey := range []string{"$defs", "definitions"} {
+		embeddedDefinitions, _ := document[defsKey].(map[string]any)
+		for name, definition := range embeddedDefinitions {
+			converted, err := toSchema(definition)
+			if err != nil {
+				return spec.Schema{}, err
+			}
+			definitions[prefix+"."+name] = converted
+		}
+		delete(document, defsKey)
+	}
+	delete(document, "$schema")
+	delete(document, "$id")
+
+	return toSchema(document)
+}
+
+// embeddedRef translates a reference local to an embedded schema document
+// into a reference to the embedded definitions.
+func embeddedRef(prefix string, ref string) string {
+	for _, defsPrefix := range []string{"#/$defs/", "#/definitions/"} {
+		if name, ok := strings.CutPrefix(ref, defsPrefix); ok {
+			return "#/$defs/" + prefix + "." + name
+		}
+	}
+
+	if rest, ok := strings.CutPrefix(ref, "#"); ok {
+		return "#/$defs/" + prefix + rest
+	}
+
+	return ref
+}
+
+// mergeGlobals copies the global properties documented by a subchart into the
+// global definition of the parent chart.
+func mergeGlobals(definitions spec.Definitions, subchartRoot spec.Schema) {
+	subchartGlobal, ok := resolve(definitions, subchartRoot).Properties["global"]
+	if !ok {
+		return
+	}
+
+	subchartGlobal = resolve(definitions, subchartGlobal)
+	if len(subchartGlobal.Properties) == 0 {
+		return
+	}
+
+	globalName := prefixName("global")
+	global := definitions[globalName]
+	if global.Properties == nil {
+		global.Properties = map[string]spec.Schema{}
+	}
+	for name, property := range subchartGlobal.Properties {
+		if _, exists := global.Properties[name]; !exists {
+			global.Properties[name] = property
+		}
+	}
+	global.Type = []string{"object"}
+	definitions[globalName] = global
+}
+
+// resolve follows local references to definitions until it reaches a schema
+// that is not a reference.
+func resolve(definitions spec.Definitions, schema spec.Schema) spec.Schema {
+	for range len(definitions) {
+		name, ok := strings.CutPrefix(schema.Ref.String(), "#/$defs/")
+		if !ok {
+			break
+		}
+
+		target, ok := definitions[name]
+		if !ok {
+			break
+		}
+		schema = target
+	}
+
+	return schema
+}
+
+func rewriteRefs(node any, rewrite func(ref string) string) {
+	switch value := node.(type) {
+	case map[string]any:
+		for key, child := range value {
+			if ref, ok := child.(string); ok && key == "$ref" {
+				value[key] = rewrite(ref)
+				continue
+			}
+			rewriteRefs(child, rewrite)
+		}
+	case []any:
+		for _, child := range value {
+			rewriteRefs(child, rewrite)
+		}
+	}
+}
+
+func toSchema(raw any) (spec.Schema, error) {
+	data, err := json.Marshal(raw)
+	if err != nil {
+		return spec.Schema{}, err
+	}
+
+	var schema spec.Schema
+	if err := json.Unmarshal(data, &schema); err != nil {
+		return spec.Schema{}, err
+	}
+
+	return schema, nil
+}
+
+func toRaw(schema spec.Schema) (any, error) {
+	data, err := json.Marshal(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+
+	return raw, nil
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cert-manager/helm-tool/parser"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(root, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+}
+
+func TestRender_Subcharts(t *testing.T) {
+	chartDir := t.TempDir()
+	writeFiles(t, chartDir, map[string]string{
+		"Chart.yaml": `
+name: umbrella
+dependencies:
+- name: generated
+  alias: gen
+- name: handwritten
+`,
+		"values.yaml": `
+global:
+  parentValue: 1
+gen:
+  enabled: true
+`,
+		"charts/generated/Chart.yaml": "name: generated\n",
+		"charts/generated/values.yaml": `
+global:
+  generatedValue: ""
+enabled: false
+`,
+		"charts/handwritten/Chart.yaml": "name: handwritten\n",
+		"charts/handwritten/values.schema.json": `{
+			"type": "object",
+			"properties": {
+				"global": {"properties": {"handwrittenValue": {"$ref": "#/definitions/str"}}},
+				"name": {"$ref": "#/definitions/str"}
+			},
+			"definitions": {"str": {"type": "string"}}
+		}`,
+	})
+
+	document, err := parser.Load(filepath.Join(chartDir, "values.yaml"), true)
+	require.NoError(t, err)
+
+	rendered, err := RenderWithSubcharts(document, chartDir)
+	require.NoError(t, err)
+
+	var result struct {
+		Defs map[string]map[string]any `json:"$defs"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(rendered), &result))
+
+	require.JSONEq(t, `{
+		"gen": {"$ref": "#/$defs/subchart.gen"},
+		"global": {"$ref": "#/$defs/helm-values.global"},
+		"handwritten": {"$ref": "#/$defs/subchart.handwritten"}
+	}`, mustJSON(t, result.Defs["helm-values"]["properties"]))
+
+	require.JSONEq(t, `{
+		"generatedValue": {"$ref": "#/$defs/subchart.gen.helm-values.global.generatedValue"},
+		"handwrittenValue": {"$ref": "#/$defs/subchart.handwritten.str"},
+		"parentValue": {"$ref": "#/$defs/helm-values.global.parentValue"}
+	}`, mustJSON(t, result.Defs["helm-values.global"]["properties"]))
+
+	require.Contains(t, result.Defs, "subchart.gen.helm-values.enabled")
+	require.NotContains(t, result.Defs, "helm-values.gen.enabled")
+}
+
+func TestRenderWithMissingSubchart(t *testing.T) {
+	chartDir := t.TempDir()
+	writeFiles(t, chartDir, map[string]string{
+		"Chart.yaml":  "name: umbrella\ndependencies:\n- name: missing\n",
+		"values.yaml": "foo: bar\n",
+	})
+
+	document, err := parser.Load(filepath.Join(chartDir, "values.yaml"), true)
+	require.NoError(t, err)
+
+	_, err = RenderWithSubcharts(document, chartDir)
+	require.ErrorContains(t, err, "helm dependency build")
+}
+
+func TestRenderWithEmptySubchartValues(t *testing.T) {
+	chartDir := t.TempDir()
+	writeFiles(t, chartDir, map[string]string{
+		"Chart.yaml":             "name: umbrella\ndependencies:\n- name: sub\n",
+		"values.yaml":            "foo: bar\n",
+		"charts/sub/Chart.yaml":  "name: sub\n",
+		"charts/sub/values.yaml": "# Default values for sub.\n",
+	})
+
+	document, err := parser.Load(filepath.Join(chartDir, "values.yaml"), true)
+	require.NoError(t, err)
+
+	rendered, err := RenderWithSubcharts(document, chartDir)
+	require.NoError(t, err)
+	require.Contains(t, rendered, `"sub"`)
+}
@@ -0,0 +1,165 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+
+	"github.com/cert-manager/helm-tool/paths"
+)
+
+// schemaURL is the location the rendered schema is compiled at, references to
+// other files are not resolved.
+const schemaURL = "file:///values.schema.json"
+
+// noLoader refuses to load references to other files.
+type noLoader struct{}
+
+func (noLoader) Load(url string) (any, error) {
+	return nil, fmt.Errorf("cannot resolve the reference to %q", url)
+}
+
+// validateValues returns the errors of the values, decoded from YAML,
+// against the rendered schema, like Helm does on install. It fails if the
+// schema is invalid, eg. if a reference cannot be resolved.
+func validateValues(schema string, values any) ([]error, error) {
+	document, err := jsonschema.UnmarshalJSON(strings.NewReader(schema))
+	if err != nil {
+		return nil, err
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.UseLoader(noLoader{})
+	if err := compiler.AddResource(schemaURL, document); err != nil {
+		return nil, err
+	}
+
+	compiled, err := compiler.Compile(schemaURL)
+	if err != nil {
+		return nil, err
+	}
+
+	// Convert the values to their JSON representation, eg. timestamps to
+	// strings
+	data, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("cannot convert the values to JSON: %w", err)
+	}
+
+	instance, err := jsonschema.UnmarshalJSON(strings.NewReader(string(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	err = compiled.Validate(instance)
+	if err == nil {
+		return nil, nil
+	}
+
+	validationError, ok := err.(*jsonschema.ValidationError)
+	if !ok {
+		return nil, err
+	}
+
+	printer := message.NewPrinter(language.English)
+	if cycle := findRefCycle(validationError); cycle != nil {
+		return nil, fmt.Errorf("invalid schema: %s", cycle.LocalizedString(printer))
+	}
+
+	errs := validationErrors(validationError, instance, printer)
+	slices.SortFunc(errs, func(a, b error) int {
+		return strings.Compare(a.Error(), b.Error())
+	})
+
+	return errs, nil
+}
+
+// findRefCycle returns the reference cycle the validation ran into, if any.
+// It is an error of the schema, not of the values.
+func findRefCycle(err *jsonschema.ValidationError) *kind.RefCycle {
+	if cycle, ok := err.ErrorKind.(*kind.RefCycle); ok {
+		return cycle
+	}
+
+	for _, cause := range err.Causes {
+		if cycle := findRefCycle(cause); cycle != nil {
+			return cycle
+		}
+	}
+
+	return nil
+}
+
+// validationErrors flattens the error into the errors of the values that do
+// not match the schema, prefixed with their path.
+func validationErrors(err *jsonschema.ValidationError, instance any, printer *message.Printer) []error {
+	// A value that matches none of the subschemas is reported once, not with
+	// the errors of every subschema
+	switch err.ErrorKind.(type) {
+	case *kind.AnyOf, *kind.OneOf:
+		return []error{valueError(err, instance, printer)}
+	}
+
+	if len(err.Causes) == 0 {
+		return []error{valueError(err, instance, printer)}
+	}
+
+	var errs []error
+	for _, cause := range err.Causes {
+		errs = append(errs, validationErrors(cause, instance, printer)...)
+	}
+
+	return errs
+}
+
+// valueError prefixes the error with the path of the value, array items are
+// told apart from properties by walking the instance.
+func valueError(err *jsonschema.ValidationError, instance any, printer *message.Printer) error {
+	path := paths.Path{}
+	value := instance
+	for _, component := range err.InstanceLocation {
+		switch typed := value.(type) {
+		case []any:
+			index, _ := strconv.Atoi(component)
+			path = path.WithIndex(index)
+			if index >= 0 && index < len(typed) {
+				value = typed[index]
+			}
+		case map[string]any:
+			path = path.WithProperty(component)
+			value = typed[component]
+		default:
+			path = path.WithProperty(component)
+		}
+	}
+
+	location := "values"
+	if len(path) > 0 {
+		location = path.String()
+	}
+
+	return fmt.Errorf("%s: %s", location, err.ErrorKind.LocalizedString(printer))
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2026 The cert-manager Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateValues(t *testing.T) {
+	schema := `{
+		"$ref": "#/$defs/helm-values",
+		"$defs": {
+			"helm-values": {
+				"type": "object",
+				"properties": {
+					"replicas": {"type": "integer", "minimum": 1},
+					"image": {"$ref": "#/$defs/helm-values.image"},
+					"args": {"type": "array", "items": {"type": "string", "pattern": "^--"}},
+					"mode": {"anyOf": [{"enum": ["a", "b"]}, {"type": "null"}]}
+				},
+				"additionalProperties": false
+			},
+			"helm-values.image": {"type": "object", "required": ["repository"]}
+		}
+	}`
+
+	errs, err := validateValues(schema, map[string]any{
+		"replicas": 1,
+		"image":    map[string]any{"repository": "app"},
+		"args":     []any{"--verbose"},
+		"mode":     nil,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, errs)
+
+	errs, err = validateValues(schema, map[string]any{
+		"replicas": 0.5,
+		"image":    map[string]any{},
+		"args":     []any{"verbose"},
+		"mode":     "c",
+		"extra":    true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`args[0]: 'verbose' does not match pattern '^--'`,
+		`image: missing property 'repository'`,
+		`mode: 'anyOf' failed`,
+		`replicas: got number, want integer`,
+		`values: additional properties 'extra' not allowed`,
+	}, errorStrings(errs))
+}
+
+func TestValidateValues_InvalidSchema(t *testing.T) {
+	// A reference that cannot be resolved is an error, not a valid value
+	_, err := validateValues(`{"$ref": "#/$defs/missing", "$defs": {}}`, map[string]any{})
+	assert.Error(t, err)
+
+	// References to other files are not loaded
+	_, err = validateValues(`{"$ref": "shared.schema.json"}`, map[string]any{})
+	assert.ErrorContains(t, err, "cannot resolve the reference")
+
+	// A reference cycle does not recurse forever
+	_, err = validateValues(`{"$ref": "#/$defs/a", "$defs": {"a": {"$ref": "#/$defs/a"}}}`, map[string]any{})
+	assert.ErrorContains(t, err, "reference cycle")
+}
+
+func errorStrings(errs []error) []string {
+	var result []string
+	for _, err := range errs {
+		result = append(result, err.Error())
+	}
+	return result
+}